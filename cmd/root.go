@@ -3,9 +3,11 @@ package cmd
 import (
 	"os"
 
+	"github.com/dizzyfool/genna/generators/function"
 	"github.com/dizzyfool/genna/generators/model"
 	"github.com/dizzyfool/genna/generators/named"
 	"github.com/dizzyfool/genna/generators/search"
+	"github.com/dizzyfool/genna/generators/sequence"
 	"github.com/dizzyfool/genna/generators/validate"
 
 	"github.com/spf13/cobra"
@@ -35,6 +37,9 @@ func init() {
 		search.CreateCommand(),
 		validate.CreateCommand(),
 		named.CreateCommand(),
+		function.CreateCommand(),
+		sequence.CreateCommand(),
+		snapshot,
 	)
 }
 
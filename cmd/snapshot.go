@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/dizzyfool/genna/generators/base"
+	genna "github.com/dizzyfool/genna/lib"
+
+	"github.com/spf13/cobra"
+)
+
+var snapshot = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Exports database snapshot shared by generators",
+	Long: `Exports snapshot of database and prints its id, snapshot is kept until process is interrupted.
+Run generators with --snapshot flag to read database in the same state, e.g.
+
+	genna snapshot -c postgres://localhost/db > snapshot &
+	genna model -c postgres://localhost/db -o model.go --snapshot $(cat snapshot)
+	genna search -c postgres://localhost/db -o search.go --snapshot $(cat snapshot)
+	kill %1`,
+	Run: func(command *cobra.Command, args []string) {
+		conn, err := command.Flags().GetString(base.Conn)
+		if err != nil {
+			log.Printf("read flags error: %s", err)
+			return
+		}
+
+		g := genna.New(conn, nil)
+		tx, id, err := g.ExportSnapshot()
+		if err != nil {
+			log.Printf("export snapshot error: %s", err)
+			return
+		}
+
+		// snapshot is valid until exporting transaction is finished
+		defer func() { _ = tx.Rollback() }()
+
+		fmt.Println(id)
+
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		<-signals
+	},
+}
+
+func init() {
+	snapshot.Flags().StringP(base.Conn, "c", "", "connection string to your postgres database")
+	if err := snapshot.MarkFlagRequired(base.Conn); err != nil {
+		panic(err)
+	}
+}
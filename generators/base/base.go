@@ -2,11 +2,13 @@ package base
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"html/template"
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	genna "github.com/dizzyfool/genna/lib"
 	"github.com/dizzyfool/genna/model"
@@ -25,8 +27,29 @@ const (
 	// Tables is basic flag (-t) for tables to generate
 	Tables = "tables"
 
+	// Exclude is basic flag (-x) for tables not to generate
+	Exclude = "exclude"
+
 	// FollowFKs is basic flag (-f) for generate foreign keys models for selected tables
 	FollowFKs = "follow-fk"
+
+	// FollowFKDepth is basic flag for limiting levels of followed foreign keys
+	FollowFKDepth = "follow-fk-depth"
+
+	// SkipJunctions is basic flag for not generating models for many-to-many junction tables
+	SkipJunctions = "skip-junctions"
+
+	// ForeignTables is basic flag for generating models for foreign tables
+	ForeignTables = "foreign-tables"
+
+	// Decimal is basic flag for mapping numeric columns to exact decimal type
+	Decimal = "decimal"
+
+	// Snapshot is basic flag for reading database in state of exported snapshot
+	Snapshot = "snapshot"
+
+	// Timeout is basic flag for limiting time of reading database
+	Timeout = "timeout"
 )
 
 // Gen is interface for all generators
@@ -40,6 +63,12 @@ type Gen interface {
 // Packer is a function that compile entities to package
 type Packer func(entities []model.Entity) (interface{}, error)
 
+// FunctionPacker is a function that compile functions to package
+type FunctionPacker func(functions []model.Function) (interface{}, error)
+
+// SequencePacker is a function that compile sequences to package
+type SequencePacker func(sequences []model.Sequence) (interface{}, error)
+
 // Options is common options for all generators
 type Options struct {
 	// URL connection string
@@ -52,13 +81,36 @@ type Options struct {
 	// Default []string{"public.*"}
 	Tables []string
 
+	// List of tables not to generate, even if listed in Tables or followed by foreign keys
+	Exclude []string
+
 	// Generate model for foreign keys,
 	// even if Tables not listed in Tables param
 	// will not generate fks if schema not listed
 	FollowFKs bool
 
+	// Levels of followed foreign keys, 0 means no limit
+	FollowFKDepth int
+
+	// Do not generate models for junction tables,
+	// many2many relations are generated anyway
+	SkipJunctions bool
+
+	// Generate models for foreign tables too
+	ForeignTables bool
+
+	// Map numeric to decimal.Decimal instead of float64
+	UseDecimal bool
+
 	// go-pg version
 	GoPgVer int
+
+	// Id of snapshot exported by other transaction,
+	// database is read in its state if set
+	Snapshot string
+
+	// Time limit of reading database, 0 means no limit
+	Timeout time.Duration
 }
 
 // Def sets default options if empty
@@ -68,16 +120,58 @@ func (o *Options) Def() {
 	// }
 }
 
+// ReadOptions gets options of reading database, sql nulls are set by generators supporting them
+func (o Options) ReadOptions() genna.ReadOptions {
+	return genna.ReadOptions{
+		Selected:      o.Tables,
+		Excluded:      o.Exclude,
+		FollowFK:      o.FollowFKs,
+		FollowFKDepth: o.FollowFKDepth,
+		UseDecimal:    o.UseDecimal,
+		GoPGVer:       o.GoPgVer,
+		SkipJunctions: o.SkipJunctions,
+		ForeignTables: o.ForeignTables,
+	}
+}
+
+// Templates are templates of generated files
+type Templates struct {
+	// Enum is a template of enums file
+	Enum string
+	// Types is a template of composite types file, it is generated only if set and composites are used
+	Types string
+	// Base is a template of base file of one file per entity generators
+	Base string
+	// Model is a template of models file, or of every entity file of one file per entity generators
+	Model string
+}
+
 // Generator is base generator used in other generators
 type Generator struct {
 	genna.Genna
+
+	timeout time.Duration
 }
 
-// NewGenerator creates generator
-func NewGenerator(url string) Generator {
-	return Generator{
-		Genna: genna.New(url, nil),
+// NewGenerator creates generator, database is read in state of snapshot if it is set
+// reading database is cancelled after timeout if it is positive
+func NewGenerator(url, snapshot string, timeout time.Duration) Generator {
+	g := Generator{
+		Genna:   genna.New(url, nil),
+		timeout: timeout,
 	}
+	g.Snapshot = snapshot
+
+	return g
+}
+
+// readContext creates context of reading database, cancelled after timeout if it is set
+func (g Generator) readContext() (context.Context, context.CancelFunc) {
+	if g.timeout > 0 {
+		return context.WithTimeout(context.Background(), g.timeout)
+	}
+
+	return context.WithCancel(context.Background())
 }
 
 // AddFlags adds basic flags to command
@@ -94,97 +188,249 @@ func AddFlags(command *cobra.Command) {
 		panic(err)
 	}
 
-	flags.StringSliceP(Tables, "t", []string{"public.*"}, "table names for model generation separated by comma\nuse 'schema_name.*' to generate model for every table in model")
-	flags.BoolP(FollowFKs, "f", false, "generate models for foreign keys, even if it not listed in Tables")
+	flags.StringSliceP(Tables, "t", []string{"public.*"}, "table names for model generation separated by comma\nuse 'schema_name.*' to generate model for every table in model\nuse globs like 'public.audit_*' or regular expressions like 're:^geo\\..*_v\\d+$'\npartitions are skipped unless named explicitly")
+	flags.StringSliceP(Exclude, "x", []string{}, "table names or patterns not to generate models for, separated by comma\nexcluded tables are not generated even if referenced by foreign keys")
+	flags.BoolP(FollowFKs, "f", false, "generate models for foreign keys, even if it not listed in Tables\nforeign keys of those models are followed too")
+	flags.Int(FollowFKDepth, 0, "levels of foreign keys to follow, 0 means no limit")
+	flags.Bool(SkipJunctions, false, "do not generate models for many-to-many junction tables")
+	flags.Bool(ForeignTables, false, "generate models for foreign tables")
+	flags.Bool(Decimal, false, "use exact decimal.Decimal type for numeric columns\nrequires github.com/shopspring/decimal")
+	flags.String(Snapshot, "", "id of snapshot to read database in, see 'genna snapshot -h'")
+	flags.Duration(Timeout, 0, "time limit of reading database, e.g. 30s, 0 means no limit")
 
 	return
 }
 
-// ReadFlags reads basic flags from command
-func ReadFlags(command *cobra.Command) (conn, output string, tables []string, followFKs bool, err error) {
+// ReadFlags reads basic flags from command to options
+func ReadFlags(command *cobra.Command, options *Options) error {
+	var err error
 	flags := command.Flags()
 
-	if conn, err = flags.GetString(Conn); err != nil {
-		return
+	if options.URL, err = flags.GetString(Conn); err != nil {
+		return err
 	}
 
-	if output, err = flags.GetString(Output); err != nil {
-		return
+	if options.Output, err = flags.GetString(Output); err != nil {
+		return err
 	}
 
-	if tables, err = flags.GetStringSlice(Tables); err != nil {
-		return
+	if options.Tables, err = flags.GetStringSlice(Tables); err != nil {
+		return err
 	}
 
-	if followFKs, err = flags.GetBool(FollowFKs); err != nil {
-		return
+	if options.Exclude, err = flags.GetStringSlice(Exclude); err != nil {
+		return err
 	}
 
-	return
+	if options.FollowFKs, err = flags.GetBool(FollowFKs); err != nil {
+		return err
+	}
+
+	if options.FollowFKDepth, err = flags.GetInt(FollowFKDepth); err != nil {
+		return err
+	}
+
+	if options.SkipJunctions, err = flags.GetBool(SkipJunctions); err != nil {
+		return err
+	}
+
+	if options.ForeignTables, err = flags.GetBool(ForeignTables); err != nil {
+		return err
+	}
+
+	if options.UseDecimal, err = flags.GetBool(Decimal); err != nil {
+		return err
+	}
+
+	if options.Snapshot, err = flags.GetString(Snapshot); err != nil {
+		return err
+	}
+
+	if options.Timeout, err = flags.GetDuration(Timeout); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 // Generate runs whole generation process
-func (g Generator) Generate(tables []string, followFKs, useSQLNulls bool, output, tmplEnum, tmpl string, packer Packer, goPGVer int) error {
-	entities, err := g.Read(tables, followFKs, useSQLNulls, goPGVer)
+// composite types are generated to shared file if types template set
+func (g Generator) Generate(options genna.ReadOptions, output string, templates Templates, packer Packer) error {
+	entities, err := g.read(options)
 	if err != nil {
-		return fmt.Errorf("read database error: %w", err)
+		return err
 	}
 
-	if enumErr := g.GenerateFromEntities(entities, output, "/constant/enums.go", tmplEnum, packer); enumErr != nil {
+	if enumErr := g.GenerateFromEntities(entities, output, "/constant/enums.go", templates.Enum, packer); enumErr != nil {
 		return enumErr
 	}
 
-	return g.GenerateFromEntities(entities, output, "/model/model.go", tmpl, packer)
+	if templates.Types != "" && hasComposites(entities) {
+		if typesErr := g.GenerateFromEntities(entities, output, "/model/types.go", templates.Types, packer); typesErr != nil {
+			return typesErr
+		}
+	}
+
+	return g.GenerateFromEntities(entities, output, "/model/model.go", templates.Model, packer)
 }
 
-func (g Generator) GenerateToFiles(tables []string, followFKs, useSQLNulls bool, outputPath, tmplEnum, tmplBase, tmplEntities string, packer Packer, goPGVer int) error {
-	entities, err := g.Read(tables, followFKs, useSQLNulls, goPGVer)
+// GenerateToFiles runs whole generation process, every entity is generated to its own file
+func (g Generator) GenerateToFiles(options genna.ReadOptions, outputPath string, templates Templates, packer Packer) error {
+	entities, err := g.read(options)
 	if err != nil {
-		return fmt.Errorf("read database error: %w", err)
+		return err
 	}
 
-	// if baseErr := g.GenerateFromEntities(entities, outputPath, "/models/base.go", tmplBase, packer); baseErr != nil {
+	// if baseErr := g.GenerateFromEntities(entities, outputPath, "/models/base.go", templates.Base, packer); baseErr != nil {
 	// 	return baseErr
 	// }
 
-	if enumErr := g.GenerateFromEntities(entities, outputPath, "/constant/enums.go", tmplEnum, packer); enumErr != nil {
+	if enumErr := g.GenerateFromEntities(entities, outputPath, "/constant/enums.go", templates.Enum, packer); enumErr != nil {
 		return enumErr
 	}
 
+	if templates.Types != "" && hasComposites(entities) {
+		if typesErr := g.GenerateFromEntities(entities, outputPath, "/models/types.go", templates.Types, packer); typesErr != nil {
+			return typesErr
+		}
+	}
+
 	for i, entity := range entities {
-		if entityErr := g.GenerateFromEntities(entities[i:(i+1)], outputPath, "/models/"+strings.ToLower(entity.GoName)+".go", tmplEntities, packer); entityErr != nil {
+		if entityErr := g.GenerateFromEntities(entities[i:(i+1)], outputPath, "/models/"+strings.ToLower(entity.GoName)+".go", templates.Model, packer); entityErr != nil {
 			return entityErr
 		}
 	}
 	return nil
 }
 
-func (g Generator) GenerateFromEntities(entities []model.Entity, outputPath, fileName, tmpl string, packer Packer) error {
-	parsed, err := template.New("base").Parse(tmpl)
+// read reads entities from database, see readContext
+func (g Generator) read(options genna.ReadOptions) ([]model.Entity, error) {
+	ctx, cancel := g.readContext()
+	defer cancel()
+
+	result, err := g.ReadContext(ctx, options)
 	if err != nil {
-		return fmt.Errorf("parsing template error: %w", err)
+		return nil, fmt.Errorf("read database error: %w", err)
+	}
+	logUnsupportedChecks(result.Entities)
+
+	return result.Entities, nil
+}
+
+// hasComposites checks if any entity uses composite types
+func hasComposites(entities []model.Entity) bool {
+	for _, entity := range entities {
+		if len(entity.Composites) > 0 {
+			return true
+		}
 	}
 
+	return false
+}
+
+// logUnsupportedChecks reports check constraints skipped in validation
+func logUnsupportedChecks(entities []model.Entity) {
+	for _, entity := range entities {
+		for _, check := range entity.UnsupportedChecks {
+			log.Printf("unsupported check constraint on %s skipped: %s", entity.PGFullName, check)
+		}
+	}
+}
+
+func (g Generator) GenerateFromEntities(entities []model.Entity, outputPath, fileName, tmpl string, packer Packer) error {
 	pack, err := packer(entities)
 	if err != nil {
 		return fmt.Errorf("packing data error: %w", err)
 	}
 
+	if err := render(pack, outputPath+fileName, tmpl); err != nil {
+		return err
+	}
+
+	log.Printf("successfully generated %d models", len(entities))
+
+	return nil
+}
+
+// GenerateFunctions runs generation of stored functions wrappers
+// selected and excluded options are used as function names or patterns
+func (g Generator) GenerateFunctions(options genna.ReadOptions, output, tmpl string, packer FunctionPacker) error {
+	ctx, cancel := g.readContext()
+	defer cancel()
+
+	functions, err := g.ReadFunctionsContext(ctx, options)
+	if err != nil {
+		return fmt.Errorf("read database error: %w", err)
+	}
+
+	supported := make([]model.Function, 0, len(functions))
+	for _, function := range functions {
+		if !function.IsSupported() {
+			log.Printf("function %s with unsupported types skipped", function.PGFullName)
+			continue
+		}
+		supported = append(supported, function)
+	}
+
+	pack, err := packer(supported)
+	if err != nil {
+		return fmt.Errorf("packing data error: %w", err)
+	}
+
+	if err := render(pack, output, tmpl); err != nil {
+		return err
+	}
+
+	log.Printf("successfully generated %d functions", len(supported))
+
+	return nil
+}
+
+// GenerateSequences runs generation of sequence helpers
+// selected and excluded options are used as sequence or table names or patterns
+func (g Generator) GenerateSequences(options genna.ReadOptions, output, tmpl string, packer SequencePacker) error {
+	ctx, cancel := g.readContext()
+	defer cancel()
+
+	sequences, err := g.ReadSequencesContext(ctx, options)
+	if err != nil {
+		return fmt.Errorf("read database error: %w", err)
+	}
+
+	pack, err := packer(sequences)
+	if err != nil {
+		return fmt.Errorf("packing data error: %w", err)
+	}
+
+	if err := render(pack, output, tmpl); err != nil {
+		return err
+	}
+
+	log.Printf("successfully generated %d sequences", len(sequences))
+
+	return nil
+}
+
+// render executes template with packed data and saves formatted result to file
+func render(pack interface{}, fileName, tmpl string) error {
+	parsed, err := template.New("base").Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("parsing template error: %w", err)
+	}
+
 	var buffer bytes.Buffer
 	if err := parsed.ExecuteTemplate(&buffer, "base", pack); err != nil {
-		return fmt.Errorf("processing model template error: %w", err)
+		return fmt.Errorf("processing template error: %w", err)
 	}
 
-	saved, err := util.FmtAndSave(buffer.Bytes(), outputPath+fileName)
+	saved, err := util.FmtAndSave(buffer.Bytes(), fileName)
 	if err != nil {
 		if !saved {
 			return fmt.Errorf("saving file error: %w", err)
 		}
-		log.Printf("formatting file %s error: %s", outputPath+fileName, err)
+		log.Printf("formatting file %s error: %s", fileName, err)
 	}
 
-	log.Printf("successfully generated %d models", len(entities))
-
 	return nil
 }
 
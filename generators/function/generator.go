@@ -0,0 +1,97 @@
+package function
+
+import (
+	"fmt"
+
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	pkg  = "pkg"
+	gopg = "gopg"
+)
+
+// CreateCommand creates generator command
+func CreateCommand() *cobra.Command {
+	return base.CreateCommand("function", "Stored functions wrappers generator for go-pg", New())
+}
+
+// Function represents stored functions generator
+type Function struct {
+	options Options
+}
+
+// New creates generator
+func New() *Function {
+	return &Function{}
+}
+
+// Options gets options
+func (g *Function) Options() *Options {
+	return &g.options
+}
+
+// SetOptions sets options
+func (g *Function) SetOptions(options Options) {
+	g.options = options
+}
+
+// AddFlags adds flags to command
+func (g *Function) AddFlags(command *cobra.Command) {
+	base.AddFlags(command)
+
+	flags := command.Flags()
+	flags.SortFlags = false
+
+	flags.StringP(pkg, "p", util.DefaultPackage, "package for functions file")
+
+	flags.IntP(gopg, "g", 9, "specify go-pg version (8 and 9 supported)\n")
+}
+
+// ReadFlags read flags from command
+func (g *Function) ReadFlags(command *cobra.Command) error {
+	var err error
+
+	if err = base.ReadFlags(command, &g.options.Options); err != nil {
+		return err
+	}
+
+	flags := command.Flags()
+
+	if g.options.Package, err = flags.GetString(pkg); err != nil {
+		return err
+	}
+
+	if g.options.GoPgVer, err = flags.GetInt(gopg); err != nil {
+		return err
+	}
+
+	if g.options.GoPgVer != 8 && g.options.GoPgVer != 9 {
+		return fmt.Errorf("version %d not supported", g.options.GoPgVer)
+	}
+
+	// setting defaults
+	g.options.Def()
+
+	return nil
+}
+
+// Generate runs whole generation process
+func (g *Function) Generate() error {
+	options := g.options.ReadOptions()
+	options.UseSQLNulls = g.options.UseSQLNulls
+
+	return base.NewGenerator(g.options.URL, g.options.Snapshot, g.options.Timeout).
+		GenerateFunctions(options, g.options.Output, Template, g.Packer())
+}
+
+// Packer returns packer function for compile functions into package
+func (g *Function) Packer() base.FunctionPacker {
+	return func(functions []model.Function) (interface{}, error) {
+		return NewTemplatePackage(functions, g.options), nil
+	}
+}
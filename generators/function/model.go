@@ -0,0 +1,269 @@
+package function
+
+import (
+	"fmt"
+	"go/token"
+	"html/template"
+	"strconv"
+	"strings"
+
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+)
+
+const (
+	// Void is kind of functions returning nothing
+	Void = "void"
+	// Scalar is kind of functions returning single value
+	Scalar = "scalar"
+	// Row is kind of functions returning single row
+	Row = "row"
+	// Set is kind of functions returning many values or rows
+	Set = "set"
+)
+
+// reserved are names used in generated function body
+var reserved = map[string]struct{}{
+	"db": {}, "query": {}, "params": {}, "result": {}, "err": {}, "call": {}, "argument": {},
+}
+
+// TemplatePackage stores package info
+type TemplatePackage struct {
+	Package string
+
+	HasImports bool
+	Imports    []string
+
+	GoPGVer string
+	// UsesPG is set if pg package is used by any function
+	UsesPG bool
+
+	Functions []TemplateFunction
+}
+
+// NewTemplatePackage creates a package for template
+func NewTemplatePackage(functions []model.Function, options Options) TemplatePackage {
+	imports := util.NewSet()
+
+	usesPG := false
+	models := make([]TemplateFunction, len(functions))
+	for i, function := range functions {
+		models[i] = NewTemplateFunction(function)
+
+		for _, imp := range function.Imports {
+			imports.Add(imp)
+		}
+
+		usesPG = usesPG || models[i].UsesPG
+	}
+
+	goPGVer := ""
+	if options.GoPgVer == 9 {
+		goPGVer = "/v9"
+	}
+
+	return TemplatePackage{
+		Package: options.Package,
+
+		HasImports: imports.Len() > 0,
+		Imports:    imports.Elements(),
+
+		GoPGVer: goPGVer,
+		UsesPG:  usesPG,
+
+		Functions: models,
+	}
+}
+
+// TemplateFunction stores function info
+type TemplateFunction struct {
+	model.Function
+
+	Doc template.HTML
+
+	// Kind is one of Void, Scalar, Row or Set
+	Kind string
+	// Call is a go literal with sql statement without arguments
+	Call template.HTML
+
+	Args []TemplateArg
+
+	// HasStruct is set if result is returned as struct named ResultName
+	HasStruct  bool
+	ResultName string
+	Columns    []TemplateColumn
+	// ResultType is a go type of result, Element is a type of single value or row
+	ResultType string
+	Element    string
+
+	UsesPG bool
+}
+
+// NewTemplateFunction creates a function for template
+func NewTemplateFunction(function model.Function) TemplateFunction {
+	doc := fmt.Sprintf("// %s calls %s", function.GoName, function.PGFullName)
+	if function.Description != "" {
+		doc += "\n//\n" + util.Comment(function.Description)
+	}
+
+	tmpl := TemplateFunction{
+		Function: function,
+
+		Doc:  template.HTML(doc),
+		Call: template.HTML(strconv.Quote(statement(function))),
+
+		ResultName: function.GoName + "Result",
+	}
+
+	for _, arg := range function.Args {
+		a := NewTemplateArg(arg)
+		tmpl.UsesPG = tmpl.UsesPG || a.UsesPG
+		tmpl.Args = append(tmpl.Args, a)
+	}
+
+	switch {
+	case function.ReturnsVoid():
+		tmpl.Kind = Void
+		return tmpl
+	case isScalar(function):
+		tmpl.Element = function.Result[0].Type
+	default:
+		tmpl.HasStruct = true
+		tmpl.Element = tmpl.ResultName
+		for _, column := range function.Result {
+			tmpl.Columns = append(tmpl.Columns, NewTemplateColumn(column))
+		}
+	}
+
+	switch {
+	case function.ReturnsSet:
+		tmpl.Kind = Set
+		tmpl.ResultType = "[]" + tmpl.Element
+	case tmpl.HasStruct:
+		tmpl.Kind = Row
+		tmpl.ResultType = "*" + tmpl.Element
+	default:
+		tmpl.Kind = Scalar
+		tmpl.ResultType = tmpl.Element
+		tmpl.UsesPG = true
+	}
+
+	return tmpl
+}
+
+// isScalar checks if function result could be read as single value
+// arrays and hstore need struct tags to be read, so they are returned in struct
+func isScalar(function model.Function) bool {
+	if len(function.Result) != 1 {
+		return false
+	}
+
+	column := function.Result[0]
+	return !column.IsArray && column.PGType != model.TypePGHstore
+}
+
+// statement gets sql for function call without arguments
+func statement(function model.Function) string {
+	name := quote(function.PGSchema) + "." + quote(function.PGName)
+
+	switch {
+	case function.Procedure:
+		return "call " + name
+	case function.ReturnsVoid():
+		return "select " + name
+	}
+
+	return "select * from " + name
+}
+
+// quote quotes pg identifier
+func quote(s string) string {
+	return `"` + strings.Replace(s, `"`, `""`, -1) + `"`
+}
+
+// TemplateArg stores function argument info
+type TemplateArg struct {
+	model.Argument
+
+	// Name is go parameter name, Quoted is go literal with pg name used in named notation
+	Name   string
+	Quoted template.HTML
+	// Value is go expression passed to query
+	Value template.HTML
+	// Optional is set if argument could be omitted by passing nil
+	Optional bool
+
+	UsesPG bool
+}
+
+// NewTemplateArg creates an argument for template
+func NewTemplateArg(arg model.Argument) TemplateArg {
+	name := lowerInitial(arg.GoName)
+	if _, ok := reserved[name]; ok || token.IsKeyword(name) {
+		name += "Arg"
+	}
+
+	tmpl := TemplateArg{
+		Argument: arg,
+		Name:     name,
+		Quoted:   template.HTML(strconv.Quote(quote(arg.PGName))),
+		Value:    template.HTML(name),
+	}
+
+	if arg.HasDefault {
+		// nil is passed to use default value
+		tmpl.Optional = true
+		if !nillable(arg.Type) {
+			tmpl.Type = "*" + arg.Type
+		}
+	}
+
+	switch {
+	case arg.PGType == model.TypePGHstore:
+		tmpl.Value = template.HTML(fmt.Sprintf("pg.Hstore(%s)", name))
+		tmpl.UsesPG = true
+	case arg.IsArray:
+		tmpl.Value = template.HTML(fmt.Sprintf("pg.Array(%s)", name))
+		tmpl.UsesPG = true
+	}
+
+	return tmpl
+}
+
+// nillable checks if go type could be nil
+func nillable(typ string) bool {
+	return strings.HasPrefix(typ, "*") || strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[")
+}
+
+// TemplateColumn stores result column info
+type TemplateColumn struct {
+	model.Column
+
+	Tag template.HTML
+}
+
+// NewTemplateColumn creates a result column for template
+func NewTemplateColumn(column model.Column) TemplateColumn {
+	tags := util.NewAnnotation()
+	tags.AddTag("pg", column.PGName)
+
+	if column.PGType == model.TypePGHstore {
+		tags.AddTag("pg", "hstore")
+	} else if column.IsArray {
+		tags.AddTag("pg", "array")
+	}
+
+	return TemplateColumn{
+		Column: column,
+		Tag:    template.HTML(fmt.Sprintf("`%s`", tags.String())),
+	}
+}
+
+// lowerInitial lowers leading upper case letters, so initialisms like IDs become ids
+func lowerInitial(s string) string {
+	i := 0
+	for i < len(s) && util.IsUpper(s[i]) {
+		i++
+	}
+	return strings.ToLower(s[:i]) + s[i:]
+}
@@ -0,0 +1,179 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/dizzyfool/genna/model"
+)
+
+func column(name, pgType string, array bool) model.Column {
+	dims := 0
+	if array {
+		dims = 1
+	}
+	return model.NewColumn(name, pgType, true, false, array, dims, false, false, 0, "", nil, 9)
+}
+
+func TestNewTemplateFunction(t *testing.T) {
+	tests := []struct {
+		name       string
+		function   func() model.Function
+		wantKind   string
+		wantType   string
+		wantCall   string
+		wantStruct bool
+	}{
+		{
+			name: "Should call procedure",
+			function: func() model.Function {
+				return model.NewFunction("public", "refresh", model.TypePGVoid, false, true)
+			},
+			wantKind: Void,
+			wantCall: `"call \"public\".\"refresh\""`,
+		},
+		{
+			name: "Should select void function",
+			function: func() model.Function {
+				return model.NewFunction("public", "touch", model.TypePGVoid, false, false)
+			},
+			wantKind: Void,
+			wantCall: `"select \"public\".\"touch\""`,
+		},
+		{
+			name: "Should return scalar",
+			function: func() model.Function {
+				f := model.NewFunction("public", "next_id", model.TypePGInt8, false, false)
+				f.AddResult(column("next_id", model.TypePGInt8, false))
+				return f
+			},
+			wantKind: Scalar,
+			wantType: model.TypeInt64,
+			wantCall: `"select * from \"public\".\"next_id\""`,
+		},
+		{
+			name: "Should return array in struct",
+			function: func() model.Function {
+				f := model.NewFunction("public", "tags", model.TypePGText, false, false)
+				f.AddResult(column("tags", model.TypePGText, true))
+				return f
+			},
+			wantKind:   Row,
+			wantType:   "*PublicTagsResult",
+			wantCall:   `"select * from \"public\".\"tags\""`,
+			wantStruct: true,
+		},
+		{
+			name: "Should return set of rows",
+			function: func() model.Function {
+				f := model.NewFunction("geo", "countries", model.TypePGRecord, true, false)
+				f.AddResult(column("code", model.TypePGVarchar, false))
+				f.AddResult(column("name", model.TypePGText, false))
+				return f
+			},
+			wantKind:   Set,
+			wantType:   "[]GeoCountriesResult",
+			wantCall:   `"select * from \"geo\".\"countries\""`,
+			wantStruct: true,
+		},
+		{
+			name: "Should return set of values",
+			function: func() model.Function {
+				f := model.NewFunction("public", "ids", model.TypePGInt4, true, false)
+				f.AddResult(column("ids", model.TypePGInt4, false))
+				return f
+			},
+			wantKind: Set,
+			wantType: "[]" + model.TypeInt,
+			wantCall: `"select * from \"public\".\"ids\""`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewTemplateFunction(tt.function())
+			if got.Kind != tt.wantKind {
+				t.Errorf("NewTemplateFunction() Kind = %v, want %v", got.Kind, tt.wantKind)
+			}
+			if got.ResultType != tt.wantType {
+				t.Errorf("NewTemplateFunction() ResultType = %v, want %v", got.ResultType, tt.wantType)
+			}
+			if string(got.Call) != tt.wantCall {
+				t.Errorf("NewTemplateFunction() Call = %v, want %v", got.Call, tt.wantCall)
+			}
+			if got.HasStruct != tt.wantStruct {
+				t.Errorf("NewTemplateFunction() HasStruct = %v, want %v", got.HasStruct, tt.wantStruct)
+			}
+		})
+	}
+}
+
+func TestNewTemplateArg(t *testing.T) {
+	tests := []struct {
+		name         string
+		arg          model.Argument
+		wantName     string
+		wantValue    string
+		wantType     string
+		wantOptional bool
+	}{
+		{
+			name:      "Should use camel cased name",
+			arg:       model.Argument{Column: column("user_id", model.TypePGInt4, false)},
+			wantName:  "userID",
+			wantValue: "userID",
+			wantType:  model.TypeInt,
+		},
+		{
+			name:      "Should lower initialism",
+			arg:       model.Argument{Column: column("id", model.TypePGInt4, false)},
+			wantName:  "id",
+			wantValue: "id",
+			wantType:  model.TypeInt,
+		},
+		{
+			name:      "Should rename keyword",
+			arg:       model.Argument{Column: column("type", model.TypePGText, false)},
+			wantName:  "typeArg",
+			wantValue: "typeArg",
+			wantType:  model.TypeString,
+		},
+		{
+			name:      "Should rename reserved name",
+			arg:       model.Argument{Column: column("query", model.TypePGText, false)},
+			wantName:  "queryArg",
+			wantValue: "queryArg",
+			wantType:  model.TypeString,
+		},
+		{
+			name:         "Should use pointer for default",
+			arg:          model.Argument{Column: column("limit", model.TypePGInt4, false), HasDefault: true},
+			wantName:     "limit",
+			wantValue:    "limit",
+			wantType:     "*" + model.TypeInt,
+			wantOptional: true,
+		},
+		{
+			name:      "Should wrap array",
+			arg:       model.Argument{Column: column("ids", model.TypePGInt4, true)},
+			wantName:  "ids",
+			wantValue: "pg.Array(ids)",
+			wantType:  "[]" + model.TypeInt,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewTemplateArg(tt.arg)
+			if got.Name != tt.wantName {
+				t.Errorf("NewTemplateArg() Name = %v, want %v", got.Name, tt.wantName)
+			}
+			if string(got.Value) != tt.wantValue {
+				t.Errorf("NewTemplateArg() Value = %v, want %v", got.Value, tt.wantValue)
+			}
+			if got.Type != tt.wantType {
+				t.Errorf("NewTemplateArg() Type = %v, want %v", got.Type, tt.wantType)
+			}
+			if got.Optional != tt.wantOptional {
+				t.Errorf("NewTemplateArg() Optional = %v, want %v", got.Optional, tt.wantOptional)
+			}
+		})
+	}
+}
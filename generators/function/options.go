@@ -0,0 +1,28 @@
+package function
+
+import (
+	"strings"
+
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/util"
+)
+
+// Options for generator
+type Options struct {
+	base.Options
+
+	// Package sets package name for functions
+	Package string
+
+	// use sql.Null... instead of pointers
+	UseSQLNulls bool
+}
+
+// Def fills default values of an options
+func (o *Options) Def() {
+	o.Options.Def()
+
+	if strings.Trim(o.Package, " ") == "" {
+		o.Package = util.DefaultPackage
+	}
+}
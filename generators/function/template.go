@@ -0,0 +1,77 @@
+package function
+
+const Template = `//nolint
+//lint:file-ignore U1000 ignore unused code, it's generated
+package {{.Package}}
+
+import ({{if .HasImports}}{{range .Imports}}
+	"{{.}}"{{end}}
+	{{end}}
+	"strings"
+	{{if .UsesPG}}
+	"github.com/go-pg/pg{{.GoPGVer}}"{{end}}
+	"github.com/go-pg/pg{{.GoPGVer}}/orm"
+)
+
+// argument is a function argument, it is omitted if default value should be used
+type argument struct {
+	name     string
+	value    interface{}
+	omit     bool
+	variadic bool
+}
+
+// call builds function call, arguments after omitted ones are passed in named notation
+func call(function string, args ...argument) (string, []interface{}) {
+	placeholders := make([]string, 0, len(args))
+	params := make([]interface{}, 0, len(args))
+
+	named := false
+	for _, arg := range args {
+		if arg.omit {
+			named = true
+			continue
+		}
+
+		placeholder := "?"
+		if named {
+			placeholder = arg.name + " => ?"
+		}
+		if arg.variadic {
+			placeholder = "variadic " + placeholder
+		}
+
+		placeholders = append(placeholders, placeholder)
+		params = append(params, arg.value)
+	}
+
+	return function + "(" + strings.Join(placeholders, ", ") + ")", params
+}
+{{range .Functions}}{{if .HasStruct}}
+// {{.ResultName}} is a row returned by {{.PGFullName}}
+type {{.ResultName}} struct { {{range .Columns}}
+	{{.GoName}} {{.Type}} {{.Tag}}{{end}}
+}
+{{end}}
+{{.Doc}}
+func {{.GoName}}(db orm.DB{{range .Args}}, {{.Name}} {{.Type}}{{end}}) {{if eq .Kind "void"}}error{{else}}({{.ResultType}}, error){{end}} {
+	query, params := call({{.Call}}{{range .Args}},
+		argument{name: {{.Quoted}}, value: {{.Value}}{{if .Optional}}, omit: {{.Name}} == nil{{end}}{{if .Variadic}}, variadic: true{{end}}}{{end}},
+	)
+	{{if eq .Kind "void"}}
+	_, err := db.Exec(query, params...)
+	return err{{else if eq .Kind "scalar"}}
+	var result {{.ResultType}}
+	_, err := db.QueryOne(pg.Scan(&result), query, params...)
+	return result, err{{else if eq .Kind "row"}}
+	result := &{{.Element}}{}
+	if _, err := db.QueryOne(result, query, params...); err != nil {
+		return nil, err
+	}
+	return result, nil{{else}}
+	var result {{.ResultType}}
+	_, err := db.Query(&result, query, params...)
+	return result, err{{end}}
+}
+{{end}}
+`
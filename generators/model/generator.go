@@ -68,8 +68,7 @@ func (g *Basic) AddFlags(command *cobra.Command) {
 func (g *Basic) ReadFlags(command *cobra.Command) error {
 	var err error
 
-	g.options.URL, g.options.Output, g.options.Tables, g.options.FollowFKs, err = base.ReadFlags(command)
-	if err != nil {
+	if err = base.ReadFlags(command, &g.options.Options); err != nil {
 		return err
 	}
 
@@ -115,22 +114,24 @@ func (g *Basic) ReadFlags(command *cobra.Command) error {
 
 // Generate runs whole generation process
 func (g *Basic) Generate() error {
-	return base.NewGenerator(g.options.URL).
-		Generate(
-			g.options.Tables,
-			g.options.FollowFKs,
-			g.options.UseSQLNulls,
-			g.options.Output,
-			EnumTemplate,
-			Template,
-			g.Packer(),
-			g.options.GoPgVer,
-		)
+	options := g.options.ReadOptions()
+	options.UseSQLNulls = g.options.UseSQLNulls
+
+	return base.NewGenerator(g.options.URL, g.options.Snapshot, g.options.Timeout).
+		Generate(options, g.options.Output, base.Templates{
+			Enum:  EnumTemplate,
+			Types: TypesTemplate,
+			Model: Template,
+		}, g.Packer())
 }
 
 // Packer returns packer function for compile entities into package
 func (g *Basic) Packer() base.Packer {
 	return func(entities []model.Entity) (interface{}, error) {
+		if err := checkCompositeArrays(entities, g.options); err != nil {
+			return nil, err
+		}
+
 		return NewTemplatePackage(entities, g.options), nil
 	}
 }
@@ -3,6 +3,7 @@ package model
 import (
 	"fmt"
 	"html/template"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -17,7 +18,12 @@ type TemplatePackage struct {
 	HasImports bool
 	Imports    []string
 	HasEnums   bool
-	Enums      []util.Enum
+	Enums      []model.Enum
+
+	HasComposites       bool
+	Composites          []TemplateComposite
+	HasCompositeImports bool
+	CompositeImports    []string
 
 	Entities []TemplateEntity
 }
@@ -25,8 +31,12 @@ type TemplatePackage struct {
 // NewTemplatePackage creates a package for template
 func NewTemplatePackage(entities []model.Entity, options Options) TemplatePackage {
 	imports := util.NewSet()
-	enums := util.NewSetEnum()
+	enums := util.NewSet()
+	composites := util.NewSet()
+	compositeImports := util.NewSet()
 
+	var packEnums []model.Enum
+	var packComposites []TemplateComposite
 	models := make([]TemplateEntity, len(entities))
 	for i, entity := range entities {
 		for _, imp := range entity.Imports {
@@ -34,10 +44,30 @@ func NewTemplatePackage(entities []model.Entity, options Options) TemplatePackag
 		}
 
 		for _, enm := range entity.Enums {
-			enums.Add(enm)
+			if enums.Add(enm.PGFullName) {
+				packEnums = append(packEnums, enm)
+			}
+		}
+
+		for _, composite := range entity.Composites {
+			if composites.Add(composite.PGFullName) {
+				packComposites = append(packComposites, NewTemplateComposite(composite, options))
+				for _, imp := range composite.Imports {
+					compositeImports.Add(imp)
+				}
+			}
+		}
+
+		if len(entity.Composites) > 0 && options.GoPgVer == 9 {
+			compositeImports.Add(model.CompositeImport)
+			compositeImports.Add("github.com/go-pg/pg/v9/types")
 		}
 
 		models[i] = NewTemplateEntity(entity, options)
+
+		if entity.Materialized || models[i].HasFinders || models[i].GeneratedColumns != "" {
+			imports.Add(ormImport(options))
+		}
 	}
 
 	imports.Add("time")
@@ -47,8 +77,13 @@ func NewTemplatePackage(entities []model.Entity, options Options) TemplatePackag
 
 		HasImports: imports.Len() > 0,
 		Imports:    imports.Elements(),
-		HasEnums:   enums.Len() > 0,
-		Enums:      enums.Elements(),
+		HasEnums:   len(packEnums) > 0,
+		Enums:      packEnums,
+
+		HasComposites:       len(packComposites) > 0,
+		Composites:          packComposites,
+		HasCompositeImports: compositeImports.Len() > 0,
+		CompositeImports:    compositeImports.Elements(),
 
 		Entities: models,
 	}
@@ -59,15 +94,30 @@ type TemplateEntity struct {
 	model.Entity
 
 	Tag template.HTML
+	Doc template.HTML
 
 	NoAlias bool
 	Alias   string
 
+	// Parents are embedded structs of inherited tables
+	Parents []string
 	Columns []TemplateColumn
 
+	// ColumnsStruct is set if struct of columns only is embedded by inheriting structs
+	ColumnsStruct bool
+
 	HasRelations bool
 	Relations    []TemplateRelation
 
+	HasInverseRelations bool
+	InverseRelations    []TemplateRelation
+
+	HasFinders bool
+	UniqueKeys []TemplateUniqueKey
+
+	// GeneratedColumns are quoted names of columns written by database only
+	GeneratedColumns template.HTML
+
 	HasCreateBy  bool
 	HasCreateDt  bool
 	HasUpdateBy  bool
@@ -106,11 +156,40 @@ func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
 			hasArchiveDt = true
 		}
 		columns[i] = NewTemplateColumn(entity, column, options)
+		columns[i].Embedded = column.IsInherited && entity.HasParents()
+	}
+
+	parents := make([]string, len(entity.Parents))
+	for i, parent := range entity.Parents {
+		parents[i] = parentStruct(*parent)
 	}
 
 	relations := make([]TemplateRelation, len(entity.Relations))
 	for i, relation := range entity.Relations {
-		relations[i] = NewTemplateRelation(relation, options)
+		relations[i] = NewTemplateRelation(entity, relation, options)
+	}
+
+	inverseRelations := make([]TemplateRelation, len(entity.InverseRelations))
+	for i, relation := range entity.InverseRelations {
+		inverseRelations[i] = NewTemplateRelation(entity, relation, options)
+	}
+
+	// go-pg has no tag to skip column on update, so list is generated
+	var generated []string
+	for _, column := range entity.Columns {
+		if column.IsGeneratedAlways() && !column.IsPK && !entity.ReadOnly {
+			generated = append(generated, strconv.Quote(column.PGName))
+		}
+	}
+
+	hasFinders := false
+	keyIndex := util.NewIndex()
+	uniqueKeys := make([]TemplateUniqueKey, 0, len(entity.UniqueKeys))
+	for _, index := range entity.UniqueKeys {
+		key := NewTemplateUniqueKey(entity, index, &keyIndex)
+
+		hasFinders = hasFinders || key.HasFinder
+		uniqueKeys = append(uniqueKeys, key)
 	}
 
 	tagName := tagName(options)
@@ -128,6 +207,12 @@ func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
 		tags.AddTag(tagName, fmt.Sprintf("alias:%s", entity.PGName))
 	}
 
+	// partition key is used by go-pg only to create table
+	if entity.IsPartitioned() && options.GoPgVer == 9 {
+		key := strings.NewReplacer(`"`, `\"`, `'`, `\'`).Replace(entity.PartitionKey)
+		tags.AddTag(tagName, fmt.Sprintf("partitionBy:'%s'", key))
+	}
+
 	if !options.NoDiscard {
 		// leading comma is required
 		tags.AddTag("pg", ",discard_unknown_columns")
@@ -136,15 +221,27 @@ func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
 	return TemplateEntity{
 		Entity: entity,
 		Tag:    template.HTML(fmt.Sprintf("`%s`", tags.String())),
+		Doc:    template.HTML(util.Comment(entity.Description)),
 
 		NoAlias: options.NoAlias,
 		Alias:   entity.PGName,
 
+		Parents: parents,
 		Columns: columns,
 
+		ColumnsStruct: columnsStruct(entity),
+
 		HasRelations: len(relations) > 0,
 		Relations:    relations,
 
+		HasInverseRelations: len(inverseRelations) > 0,
+		InverseRelations:    inverseRelations,
+
+		HasFinders: hasFinders,
+		UniqueKeys: uniqueKeys,
+
+		GeneratedColumns: template.HTML(strings.Join(generated, ", ")),
+
 		HasCreateBy:  hasCreateBy,
 		HasCreateDt:  hasCreateDt,
 		HasUpdateBy:  hasUpdateBy,
@@ -154,12 +251,31 @@ func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
 	}
 }
 
+// columnsStruct checks if inheriting structs should embed columns of entity only
+// go-pg would join relations of embedded struct on table of inheriting one
+func columnsStruct(entity model.Entity) bool {
+	return entity.IsParent && entity.HasRelations()
+}
+
+// parentStruct gets name of struct embedded for parent entity
+func parentStruct(parent model.Entity) string {
+	if columnsStruct(parent) {
+		return parent.GoName + "Columns"
+	}
+
+	return parent.GoName
+}
+
 // TemplateColumn stores column info
 type TemplateColumn struct {
 	model.Column
 
 	Tag     template.HTML
 	Comment template.HTML
+	Doc     template.HTML
+
+	// Embedded is set if column comes with embedded parent struct
+	Embedded bool
 }
 
 // NewTemplateColumn creates a column for template
@@ -184,6 +300,11 @@ func NewTemplateColumn(entity model.Entity, column model.Column, options Options
 		tags.AddTag(tagName, "pk")
 	}
 
+	// unique tag
+	if column.IsUnique {
+		tags.AddTag(tagName, "unique")
+	}
+
 	// types tag
 	if column.PGType == model.TypePGHstore {
 		tags.AddTag(tagName, "hstore")
@@ -193,9 +314,15 @@ func NewTemplateColumn(entity model.Entity, column model.Column, options Options
 	if column.PGType == model.TypePGUuid {
 		tags.AddTag(tagName, "type:uuid")
 	}
+	if column.Composite != nil && !column.IsArray {
+		tags.AddTag(tagName, "composite:"+column.Composite.TypeName())
+	}
 
 	// nullable tag
-	if !column.Nullable && !column.IsPK {
+	// zero value is written as DEFAULT, so it is kept only for literal defaults
+	// zero of sequence or function default means database should fill it
+	if !column.Nullable && !column.IsPK && !entity.ReadOnly &&
+		(!column.HasDefault() || column.DefaultKind == model.DefaultLiteral) && !column.IsManaged() {
 		if options.GoPgVer == 9 {
 			tags.AddTag(tagName, "use_zero")
 		} else {
@@ -203,6 +330,24 @@ func NewTemplateColumn(entity model.Entity, column model.Column, options Options
 		}
 	}
 
+	// default tag
+	if def, ok := defaultTag(column); ok {
+		tags.AddTag(tagName, "default:"+def)
+	}
+
+	// literal default comment
+	if column.DefaultKind == model.DefaultLiteral {
+		comment = "// default: " + defaultComment(column)
+	}
+
+	// database managed comment
+	switch {
+	case column.Generated:
+		comment = "// generated"
+	case column.Identity != "":
+		comment = "// identity"
+	}
+
 	// soft_delete tag
 	if options.SoftDelete == column.PGName && column.Nullable && column.GoType == model.TypeTime && !column.IsArray {
 		tags.AddTag("pg", ",soft_delete")
@@ -228,7 +373,18 @@ func NewTemplateColumn(entity model.Entity, column model.Column, options Options
 	// 	tags.AddTag("json", "string")
 	// }
 
-	if !column.Nullable {
+	// views and database managed columns are never written, so no validation needed
+	if entity.ReadOnly || column.IsManaged() {
+		return TemplateColumn{
+			Column: column,
+
+			Tag:     template.HTML(fmt.Sprintf("`%s`", tags.String())),
+			Comment: template.HTML(comment),
+			Doc:     template.HTML(util.Comment(column.Description)),
+		}
+	}
+
+	if !column.Nullable && !column.HasDefault() {
 		tags.AddTag("validate", "required")
 	}
 
@@ -245,13 +401,40 @@ func NewTemplateColumn(entity model.Entity, column model.Column, options Options
 	// 	return Zero
 	// }
 
-	// validate enum
-	if len(column.Values) > 0 {
-		if column.Nullable {
-			tags.AddTag("validate", "omitempty")
+	checks := make([]string, 0, len(column.Checks))
+	notNull := false
+	for _, rule := range column.Checks {
+		if !rule.AppliesTo(column) {
+			continue
+		}
+		if rule.Kind == model.CheckNotNull {
+			notNull = true
+			continue
+		}
+		if tag, ok := checkTag(rule); ok {
+			checks = append(checks, tag)
 		}
+	}
+
+	if column.Nullable && notNull {
+		tags.AddTag("validate", "required")
+	}
+
+	if column.Nullable && !notNull && (len(column.Values) > 0 || len(checks) > 0) {
+		tags.AddTag("validate", "omitempty")
+	}
+
+	// validate check constraints
+	for _, check := range checks {
+		tags.AddTag("validate", check)
+	}
+
+	// validate enum, every element of arrays is checked
+	if len(column.Values) > 0 {
 		if column.IsArray {
-			tags.AddTag("validate", "dive")
+			for i := 0; i < column.Dimensions; i++ {
+				tags.AddTag("validate", "dive")
+			}
 		}
 		tags.AddTag("validate", "oneof="+fmt.Sprintf(`'%s'`, strings.Join(column.Values, `' '`)))
 	}
@@ -268,6 +451,154 @@ func NewTemplateColumn(entity model.Entity, column model.Column, options Options
 
 		Tag:     template.HTML(fmt.Sprintf("`%s`", tags.String())),
 		Comment: template.HTML(comment),
+		Doc:     template.HTML(util.Comment(column.Description)),
+	}
+}
+
+// checkTag translates check rule to validator tag
+func checkTag(rule model.CheckRule) (string, bool) {
+	for _, value := range append([]string{rule.Value}, rule.Values...) {
+		// these symbols can not be escaped in validate tag
+		if strings.ContainsAny(value, "',|\"`") {
+			return "", false
+		}
+	}
+
+	switch rule.Kind {
+	case model.CheckCompare:
+		ops := map[string]string{"=": "eq", "<>": "ne", "<": "lt", "<=": "lte", ">": "gt", ">=": "gte"}
+		return ops[rule.Operator] + "=" + rule.Value, true
+	case model.CheckLength:
+		ops := map[string]string{"=": "len", "<": "lt", "<=": "max", ">": "gt", ">=": "min"}
+		if op, ok := ops[rule.Operator]; ok {
+			return op + "=" + rule.Value, true
+		}
+	case model.CheckIn:
+		if rule.IsString {
+			return "oneof=" + fmt.Sprintf(`'%s'`, strings.Join(rule.Values, `' '`)), true
+		}
+		return "oneof=" + strings.Join(rule.Values, " "), true
+	}
+
+	return "", false
+}
+
+// TemplateComposite stores composite type struct info
+type TemplateComposite struct {
+	model.Composite
+
+	// ArrayMethods are scan and append methods used by go-pg v9 for elements of arrays
+	ArrayMethods bool
+
+	Fields []TemplateCompositeField
+}
+
+// TemplateCompositeField stores composite attribute info
+type TemplateCompositeField struct {
+	model.Column
+
+	Tag template.HTML
+}
+
+// NewTemplateComposite creates a composite for template
+// fields are never skipped, because go-pg scans composites by position
+func NewTemplateComposite(composite model.Composite, options Options) TemplateComposite {
+	tagName := tagName(options)
+
+	fields := make([]TemplateCompositeField, len(composite.Fields))
+	for i, field := range composite.Fields {
+		tags := util.NewAnnotation()
+		tags.AddTag(tagName, field.PGName)
+
+		switch {
+		case field.PGType == model.TypePGHstore:
+			tags.AddTag(tagName, "hstore")
+		case field.IsArray:
+			tags.AddTag(tagName, "array")
+		case field.Composite != nil:
+			tags.AddTag(tagName, "composite:"+field.Composite.TypeName())
+		}
+
+		tags.AddTag("json", util.LowerFirst(field.GoName))
+
+		fields[i] = TemplateCompositeField{
+			Column: field,
+			Tag:    template.HTML(fmt.Sprintf("`%s`", tags.String())),
+		}
+	}
+
+	return TemplateComposite{
+		Composite:    composite,
+		ArrayMethods: options.GoPgVer == 9,
+		Fields:       fields,
+	}
+}
+
+// TemplateUniqueKey stores unique key info
+type TemplateUniqueKey struct {
+	model.Index
+
+	GoName string
+
+	// Conflict is a quoted conflict target for upsert
+	Conflict template.HTML
+
+	// Where is a quoted condition used by finder, finder is not generated for expression keys
+	HasFinder bool
+	Where     template.HTML
+}
+
+// NewTemplateUniqueKey creates unique key for template
+func NewTemplateUniqueKey(entity model.Entity, index model.Index, keyIndex *util.Index) TemplateUniqueKey {
+	hasFinder := !index.HasExpressions
+	names := make([]string, len(index.Columns))
+	targets := make([]string, len(index.Columns))
+	elements := make([]string, len(index.Columns))
+	where := make([]string, len(index.Columns))
+
+	for i, name := range index.Columns {
+		targets[i] = name
+		names[i] = util.ColumnName(name)
+		// expressions in conflict target must be parenthesized, e.g. ((a + b))
+		elements[i] = "(" + name + ")"
+
+		for _, column := range entity.Columns {
+			if column.PGName == name {
+				targets[i] = `"` + name + `"`
+				elements[i] = targets[i]
+				names[i] = column.GoName
+			}
+		}
+
+		// go-pg uses ?column as model field value
+		if !paramRegEx.MatchString(name) {
+			hasFinder = false
+		}
+		where[i] = fmt.Sprintf("%s = ?%s", targets[i], name)
+	}
+
+	goName := strings.Join(names, "")
+	if index.HasExpressions {
+		goName = util.CamelCased(util.Sanitize(index.Name))
+	}
+	goName = keyIndex.GetNext(goName)
+	keyIndex.Add(goName)
+
+	conflict := "(" + strings.Join(elements, ", ") + ")"
+	condition := strings.Join(where, " AND ")
+	if index.IsPartial() {
+		conflict += " WHERE " + index.Predicate
+		condition += " AND (" + index.Predicate + ")"
+	}
+
+	return TemplateUniqueKey{
+		Index: index,
+
+		GoName:   goName,
+		Conflict: template.HTML(strconv.Quote(conflict)),
+
+		HasFinder: hasFinder,
+		Where:     template.HTML(strconv.Quote(condition)),
 	}
 }
 
@@ -280,13 +611,33 @@ type TemplateRelation struct {
 }
 
 // NewTemplateRelation creates relation for template
-func NewTemplateRelation(relation model.Relation, options Options) TemplateRelation {
+// inverse relations are joined by fk too: has-many by slice, has-one by struct
+func NewTemplateRelation(entity model.Entity, relation model.Relation, options Options) TemplateRelation {
 	comment := ""
 	tagName := tagName(options)
-	tags := util.NewAnnotation().AddTag("pg", "fk:"+strings.Join(relation.FKFields, ","))
-	if len(relation.FKFields) > 1 {
+	tags := util.NewAnnotation()
+	if relation.Many2Many != "" {
+		tags.AddTag("pg", "many2many:"+relation.Many2Many)
+	}
+
+	fk, fkOK := fkTag(relation, options.GoPgVer)
+	if fk != "" {
+		tags.AddTag("pg", "fk:"+fk)
+	}
+
+	if relation.Many2Many != "" {
+		tags.AddTag("pg", "joinFK:"+strings.Join(relation.JoinFKFields, ","))
+	}
+
+	// go-pg tries to join struct as has-one first, inverse has-one must not be joined that way
+	if relation.Inverse && !relation.HasMany && fkOK && !joinedAsInverse(entity, relation, fk) {
+		fkOK = false
+	}
+
+	// go-pg joins by primary key of referenced table, so relations to unique keys would be joined wrong
+	if !fkOK || len(relation.JoinFKFields) > 1 || (relation.Many2Many == "" && !relation.RefPK) {
 		comment = "// unsupported"
-		tags.AddTag(tagName, "-")
+		tags = util.NewAnnotation().AddTag(tagName, "-")
 	}
 
 	tags.AddTag("json", "-")
@@ -299,6 +650,147 @@ func NewTemplateRelation(relation model.Relation, options Options) TemplateRelat
 	}
 }
 
+// joinedAsInverse checks that go-pg joins inverse has-one relation by its fk columns
+// go-pg joins struct as has-one if entity has columns named by fk and primary keys of target,
+// it is the same join only if those are referenced columns
+func joinedAsInverse(entity model.Entity, relation model.Relation, fk string) bool {
+	if relation.TargetEntity == nil {
+		return false
+	}
+
+	if fk == "" {
+		fk = util.Underscore(relation.GoName) + "_"
+	}
+
+	columns := util.NewSet()
+	for _, column := range entity.Columns {
+		columns.Add(column.PGName)
+	}
+
+	// has-one join as target primary key = entity column
+	var pks []string
+	join := map[string]string{}
+	for _, column := range relation.TargetEntity.Columns {
+		if !column.IsPK {
+			continue
+		}
+		pks = append(pks, column.PGName)
+
+		switch {
+		case columns.Exists(fk + column.PGName):
+			join[column.PGName] = fk + column.PGName
+		case strings.Contains(column.PGName, "_") && columns.Exists(column.PGName):
+			join[column.PGName] = column.PGName
+		}
+	}
+
+	if len(join) != len(pks) {
+		join = map[string]string{}
+		for _, pk := range pks {
+			if strings.HasPrefix(pk, "pk_") && columns.Exists("fk_"+pk[3:]) {
+				join[pk] = "fk_" + pk[3:]
+			}
+		}
+	}
+
+	if len(join) != len(pks) {
+		join = map[string]string{}
+		if len(pks) == 1 {
+			for _, name := range []string{fk, fk + "id", fk + "uuid"} {
+				if columns.Exists(name) {
+					join[pks[0]] = name
+					break
+				}
+			}
+		}
+	}
+
+	// not joined as has-one, so joined as belongs-to
+	if len(join) == 0 {
+		return true
+	}
+
+	if len(join) != len(relation.FKFields) {
+		return false
+	}
+	for i, field := range relation.FKFields {
+		if join[field] != relation.RefFields[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fkTag gets value for fk tag, tag is not needed if value is empty
+// go-pg joins multi-column keys by fk prefix followed by referenced column name,
+// go-pg v9 also joins referenced columns with underscore by the same name
+// go-pg converts fk started with upper case letter, so such columns are not supported
+func fkTag(relation model.Relation, goPGVer int) (string, bool) {
+	unsupported := strings.Join(relation.FKFields, ",")
+
+	if len(relation.FKFields) == 1 {
+		if fk := relation.FKFields[0]; fk != "" && !util.IsUpper(fk[0]) {
+			return fk, true
+		}
+		return unsupported, false
+	}
+
+	if len(relation.FKFields) != len(relation.RefFields) {
+		return unsupported, false
+	}
+
+	if goPGVer != 9 {
+		return fkPrefix(relation)
+	}
+
+	prefix, found := "", false
+	for i, fk := range relation.FKFields {
+		ref := relation.RefFields[i]
+		if fk == ref {
+			continue
+		}
+
+		if !strings.HasSuffix(fk, ref) || (found && prefix != strings.TrimSuffix(fk, ref)) {
+			return unsupported, false
+		}
+
+		prefix, found = strings.TrimSuffix(fk, ref), true
+	}
+
+	if prefix != "" && util.IsUpper(prefix[0]) {
+		return unsupported, false
+	}
+
+	// same names are joined without prefix only if they have underscore
+	for i, fk := range relation.FKFields {
+		if ref := relation.RefFields[i]; fk == ref && !strings.Contains(ref, "_") {
+			return unsupported, false
+		}
+	}
+
+	return prefix, true
+}
+
+// fkPrefix gets common prefix of all fk columns, go-pg v8 joins multi-column keys only by it
+func fkPrefix(relation model.Relation) (string, bool) {
+	unsupported := strings.Join(relation.FKFields, ",")
+
+	prefix := strings.TrimSuffix(relation.FKFields[0], relation.RefFields[0])
+	for i, fk := range relation.FKFields {
+		if !strings.HasSuffix(fk, relation.RefFields[i]) || strings.TrimSuffix(fk, relation.RefFields[i]) != prefix {
+			return unsupported, false
+		}
+	}
+
+	// empty prefix can not be set by tag
+	if prefix == "" || util.IsUpper(prefix[0]) {
+		return unsupported, false
+	}
+
+	return prefix, true
+}
+
 func jsonType(mp map[string]string, schema, table, field string) (string, bool) {
 	if mp == nil {
 		return "", false
@@ -327,6 +819,73 @@ func jsonType(mp map[string]string, schema, table, field string) (string, bool)
 	return "", false
 }
 
+// defaultTag gets value for default tag
+// sequences are skipped, values which could break struct tag too
+func defaultTag(column model.Column) (string, bool) {
+	var value string
+
+	switch column.DefaultKind {
+	case model.DefaultLiteral:
+		value = column.DefaultValue
+		if column.GoType == model.TypeString {
+			value = "'" + strings.Replace(value, "'", `\'`, -1) + "'"
+		}
+	case model.DefaultFunction:
+		value = column.Default
+	default:
+		return "", false
+	}
+
+	if strings.ContainsAny(value, "\"`") || (column.DefaultKind == model.DefaultFunction && strings.ContainsAny(value, ",'")) {
+		return "", false
+	}
+
+	return value, true
+}
+
+// defaultComment gets literal default as go value for comment
+func defaultComment(column model.Column) string {
+	if column.GoType == model.TypeString {
+		return strconv.Quote(column.DefaultValue)
+	}
+
+	return column.DefaultValue
+}
+
+// checkCompositeArrays checks that arrays of composites could be scanned, go-pg v8 can not do it
+func checkCompositeArrays(entities []model.Entity, options Options) error {
+	if options.GoPgVer == 9 {
+		return nil
+	}
+
+	for _, entity := range entities {
+		for _, column := range entity.Columns {
+			if column.Composite != nil && column.IsArray {
+				return fmt.Errorf("array of composite type %s in %s.%s is supported only for go-pg v9", column.Composite.PGFullName, entity.PGFullName, column.PGName)
+			}
+		}
+
+		for _, composite := range entity.Composites {
+			for _, field := range composite.Fields {
+				if field.Composite != nil && field.IsArray {
+					return fmt.Errorf("array of composite type %s in %s.%s is supported only for go-pg v9", field.Composite.PGFullName, composite.PGFullName, field.PGName)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func ormImport(options Options) string {
+	if options.GoPgVer == 9 {
+		return "github.com/go-pg/pg/v9/orm"
+	}
+	return "github.com/go-pg/pg/orm"
+}
+
+var paramRegEx = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
 func tagName(options Options) string {
 	if options.GoPgVer == 9 {
 		return "pg"
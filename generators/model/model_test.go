@@ -0,0 +1,641 @@
+package model
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+)
+
+func Test_fkTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		fks     []string
+		refs    []string
+		goPGVer int
+		want    string
+		wantOK  bool
+	}{
+		{
+			name:    "Should use single column as is",
+			fks:     []string{"countryId"},
+			refs:    []string{"countryId"},
+			goPGVer: 9,
+			want:    "countryId",
+			wantOK:  true,
+		},
+		{
+			name:    "Should not support single column started with upper case letter",
+			fks:     []string{"CountryID"},
+			refs:    []string{"ID"},
+			goPGVer: 9,
+			want:    "CountryID",
+			wantOK:  false,
+		},
+		{
+			name:    "Should use prefix for composite key",
+			fks:     []string{"x_tenant_id", "x_id"},
+			refs:    []string{"tenant_id", "id"},
+			goPGVer: 9,
+			want:    "x_",
+			wantOK:  true,
+		},
+		{
+			name:    "Should join same underscored column by name",
+			fks:     []string{"tenant_id", "x_id"},
+			refs:    []string{"tenant_id", "id"},
+			goPGVer: 9,
+			want:    "x_",
+			wantOK:  true,
+		},
+		{
+			name:    "Should not need tag for same underscored names",
+			fks:     []string{"tenant_id", "project_id"},
+			refs:    []string{"tenant_id", "project_id"},
+			goPGVer: 9,
+			want:    "",
+			wantOK:  true,
+		},
+		{
+			name:    "Should not support same camelCased names",
+			fks:     []string{"tenantId", "projectId"},
+			refs:    []string{"tenantId", "projectId"},
+			goPGVer: 9,
+			want:    "tenantId,projectId",
+			wantOK:  false,
+		},
+		{
+			name:    "Should not support same camelCased column with prefix",
+			fks:     []string{"tenantId", "projectId"},
+			refs:    []string{"tenantId", "id"},
+			goPGVer: 9,
+			want:    "tenantId,projectId",
+			wantOK:  false,
+		},
+		{
+			name:    "Should not support different prefixes",
+			fks:     []string{"a_tenant_id", "b_id"},
+			refs:    []string{"tenant_id", "id"},
+			goPGVer: 9,
+			want:    "a_tenant_id,b_id",
+			wantOK:  false,
+		},
+		{
+			name:    "Should use prefix for composite key in v8",
+			fks:     []string{"x_tenant_id", "x_id"},
+			refs:    []string{"tenant_id", "id"},
+			goPGVer: 8,
+			want:    "x_",
+			wantOK:  true,
+		},
+		{
+			name:    "Should not join same underscored column by name in v8",
+			fks:     []string{"tenant_id", "x_id"},
+			refs:    []string{"tenant_id", "id"},
+			goPGVer: 8,
+			want:    "tenant_id,x_id",
+			wantOK:  false,
+		},
+		{
+			name:    "Should not support same names in v8",
+			fks:     []string{"tenant_id", "project_id"},
+			refs:    []string{"tenant_id", "project_id"},
+			goPGVer: 8,
+			want:    "tenant_id,project_id",
+			wantOK:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relation := model.NewRelation(tt.fks, util.PublicSchema, "projects", tt.refs)
+			got, ok := fkTag(relation, tt.goPGVer)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("fkTag() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNewTemplateRelation(t *testing.T) {
+	tests := []struct {
+		name    string
+		fks     []string
+		refs    []string
+		refPK   bool
+		goPGVer int
+		want    string
+	}{
+		{
+			name:    "Should join by fk",
+			fks:     []string{"projectId"},
+			refs:    []string{"projectId"},
+			refPK:   true,
+			goPGVer: 9,
+			want:    "`pg:\"fk:projectId\" json:\"-\"`",
+		},
+		{
+			name:    "Should skip relation to unique key",
+			fks:     []string{"projectCode"},
+			refs:    []string{"code"},
+			goPGVer: 9,
+			want:    "`pg:\"-\" json:\"-\"`",
+		},
+		{
+			name:    "Should omit empty fk",
+			fks:     []string{"tenant_id", "project_id"},
+			refs:    []string{"tenant_id", "project_id"},
+			refPK:   true,
+			goPGVer: 9,
+			want:    "`json:\"-\"`",
+		},
+		{
+			name:    "Should skip unsupported relation in v8",
+			fks:     []string{"tenant_id", "project_id"},
+			refs:    []string{"tenant_id", "project_id"},
+			refPK:   true,
+			goPGVer: 8,
+			want:    "`sql:\"-\" json:\"-\"`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := Options{}
+			options.GoPgVer = tt.goPGVer
+
+			relation := model.NewRelation(tt.fks, util.PublicSchema, "projects", tt.refs)
+			relation.RefPK = tt.refPK
+
+			entity := model.NewEntity(util.PublicSchema, "tasks", nil, nil)
+			if got := NewTemplateRelation(entity, relation, options); string(got.Tag) != tt.want {
+				t.Errorf("TemplateRelation.Tag = %v, want %v", got.Tag, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTemplateRelation_Inverse(t *testing.T) {
+	options := Options{}
+	options.GoPgVer = 9
+
+	column := func(name string, pk bool) model.Column {
+		return model.NewColumn(name, model.TypePGInt4, false, false, false, 0, pk, !pk, 0, "", []string{}, 9)
+	}
+
+	countries := model.NewEntity(util.PublicSchema, "countries", []model.Column{column("countryId", true)}, nil)
+	users := model.NewEntity(util.PublicSchema, "users", []model.Column{column("userId", true), column("countryId", false)}, nil)
+	profiles := model.NewEntity(util.PublicSchema, "profiles", []model.Column{column("userId", true)}, nil)
+	people := model.NewEntity(util.PublicSchema, "people", []model.Column{column("id", true)}, nil)
+	passports := model.NewEntity(util.PublicSchema, "passports", []model.Column{column("id", true), column("owner_id", false)}, nil)
+
+	tests := []struct {
+		name   string
+		entity model.Entity
+		target model.Entity
+		fks    []string
+		refs   []string
+		unique bool
+		want   string
+	}{
+		{
+			name:   "Should join has-many by fk",
+			entity: countries,
+			target: users,
+			fks:    []string{"countryId"},
+			refs:   []string{"countryId"},
+			want:   "`pg:\"fk:countryId\" json:\"-\"`",
+		},
+		{
+			name:   "Should join has-one by fk",
+			entity: people,
+			target: passports,
+			fks:    []string{"owner_id"},
+			refs:   []string{"id"},
+			unique: true,
+			want:   "`pg:\"fk:owner_id\" json:\"-\"`",
+		},
+		{
+			name:   "Should join has-one by shared primary key",
+			entity: users,
+			target: profiles,
+			fks:    []string{"userId"},
+			refs:   []string{"userId"},
+			unique: true,
+			want:   "`pg:\"fk:userId\" json:\"-\"`",
+		},
+		{
+			name:   "Should skip has-one joined by wrong columns",
+			entity: countries,
+			target: users,
+			fks:    []string{"countryId"},
+			refs:   []string{"countryId"},
+			unique: true,
+			want:   "`pg:\"-\" json:\"-\"`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relation := model.NewInverseRelation(tt.fks, tt.target.PGSchema, tt.target.PGName, tt.refs, tt.unique)
+			relation.RefPK = true
+			relation.AddEntity(&tt.target)
+
+			if got := NewTemplateRelation(tt.entity, relation, options); string(got.Tag) != tt.want {
+				t.Errorf("TemplateRelation.Tag = %v, want %v", got.Tag, tt.want)
+			}
+		})
+	}
+}
+
+func Test_checkTag(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   model.CheckRule
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "Should translate comparison",
+			rule:   model.CheckRule{Kind: model.CheckCompare, Operator: ">=", Value: "0"},
+			want:   "gte=0",
+			wantOK: true,
+		},
+		{
+			name:   "Should translate length",
+			rule:   model.CheckRule{Kind: model.CheckLength, Operator: "=", Value: "3"},
+			want:   "len=3",
+			wantOK: true,
+		},
+		{
+			name:   "Should translate string list",
+			rule:   model.CheckRule{Kind: model.CheckIn, Values: []string{"new", "in progress"}, IsString: true},
+			want:   "oneof='new' 'in progress'",
+			wantOK: true,
+		},
+		{
+			name:   "Should translate number list",
+			rule:   model.CheckRule{Kind: model.CheckIn, Values: []string{"1", "2"}},
+			want:   "oneof=1 2",
+			wantOK: true,
+		},
+		{
+			name:   "Should skip values with commas",
+			rule:   model.CheckRule{Kind: model.CheckIn, Values: []string{"a,b"}, IsString: true},
+			wantOK: false,
+		},
+		{
+			name:   "Should skip length inequality",
+			rule:   model.CheckRule{Kind: model.CheckLength, Operator: "<>", Value: "3"},
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := checkTag(tt.rule)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("checkTag() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNewTemplateComposite(t *testing.T) {
+	point := model.NewComposite(util.PublicSchema, "point2d", nil)
+	location := model.NewColumn("location", "point2d", true, false, false, 0, false, false, 0, "", []string{}, 9)
+	location.AddComposite(&point)
+	composite := model.NewComposite(util.PublicSchema, "address", []model.Column{
+		model.NewColumn("lines", model.TypePGText, true, false, true, 1, false, false, 0, "", []string{}, 9),
+		location,
+	})
+
+	options := Options{}
+	options.GoPgVer = 9
+
+	tmpl := NewTemplateComposite(composite, options)
+	want := []string{
+		"`pg:\"lines,array\" json:\"lines\"`",
+		"`pg:\"location,composite:point2d\" json:\"location\"`",
+	}
+	for i, field := range tmpl.Fields {
+		if string(field.Tag) != want[i] {
+			t.Errorf("TemplateComposite.Fields[%d].Tag = %v, want %v", i, field.Tag, want[i])
+		}
+	}
+}
+
+func TestNewTemplateColumn_Managed(t *testing.T) {
+	tests := []struct {
+		name          string
+		goPGVer       int
+		generated     bool
+		identity      string
+		sequence      string
+		want          string
+		wantGenerated template.HTML
+	}{
+		{
+			name:          "Should exclude generated column on update",
+			generated:     true,
+			want:          "`pg:\"total\" json:\"total\" form:\"total\" query:\"total\"` // generated",
+			wantGenerated: `"total"`,
+		},
+		{
+			name:          "Should exclude identity always column on update",
+			identity:      model.IdentityAlways,
+			want:          "`pg:\"total\" json:\"total\" form:\"total\" query:\"total\"` // identity",
+			wantGenerated: `"total"`,
+		},
+		{
+			name:     "Should keep identity by default column on update",
+			identity: model.IdentityByDefault,
+			want:     "`pg:\"total\" json:\"total\" form:\"total\" query:\"total\"` // identity",
+		},
+		{
+			name:          "Should not write zero to generated column in v8",
+			goPGVer:       8,
+			generated:     true,
+			want:          "`sql:\"total\" json:\"total\" form:\"total\" query:\"total\"` // generated",
+			wantGenerated: `"total"`,
+		},
+		{
+			name:     "Should not write zero to identity column in v8",
+			goPGVer:  8,
+			identity: model.IdentityByDefault,
+			want:     "`sql:\"total\" json:\"total\" form:\"total\" query:\"total\"` // identity",
+		},
+		{
+			name:     "Should not write zero to serial column in v8",
+			goPGVer:  8,
+			sequence: "orders_total_seq",
+			want:     "`sql:\"total\" json:\"total\" form:\"total\" query:\"total\"` ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := Options{}
+			options.GoPgVer = 9
+			if tt.goPGVer != 0 {
+				options.GoPgVer = tt.goPGVer
+			}
+
+			total := model.NewColumn("total", model.TypePGInt8, false, false, false, 0, false, false, 0, "", []string{}, options.GoPgVer)
+			total.Generated = tt.generated
+			total.Identity = tt.identity
+			total.Sequence = tt.sequence
+			entity := model.NewEntity(util.PublicSchema, "orders", []model.Column{total}, nil)
+
+			tmpl := NewTemplateColumn(entity, entity.Columns[0], options)
+			if got := string(tmpl.Tag) + " " + string(tmpl.Comment); got != tt.want {
+				t.Errorf("TemplateColumn.Tag = %v, want %v", got, tt.want)
+			}
+
+			if got := NewTemplateEntity(entity, options).GeneratedColumns; got != tt.wantGenerated {
+				t.Errorf("TemplateEntity.GeneratedColumns = %v, want %v", got, tt.wantGenerated)
+			}
+		})
+	}
+}
+
+func TestNewTemplateUniqueKey(t *testing.T) {
+	email := model.NewColumn("email", model.TypePGText, false, false, false, 0, false, false, 0, "", []string{}, 9)
+	tenantID := model.NewColumn("tenantId", model.TypePGInt8, false, false, false, 0, false, false, 0, "", []string{}, 9)
+	entity := model.NewEntity(util.PublicSchema, "users", []model.Column{email, tenantID}, nil)
+
+	tests := []struct {
+		name         string
+		index        model.Index
+		wantConflict template.HTML
+		wantFinder   bool
+	}{
+		{
+			name:         "Should use quoted columns",
+			index:        model.NewIndex("users_email_key", []string{"tenantId", "email"}, true, false, true, false, ""),
+			wantConflict: `"(\"tenantId\", \"email\")"`,
+			wantFinder:   true,
+		},
+		{
+			name:         "Should parenthesize expressions",
+			index:        model.NewIndex("users_lower_email_idx", []string{"tenantId", "lower(email)"}, true, false, false, true, ""),
+			wantConflict: `"(\"tenantId\", (lower(email)))"`,
+		},
+		{
+			name:         "Should add predicate of partial index",
+			index:        model.NewIndex("users_email_idx", []string{"lower(email)"}, true, false, false, true, "(email IS NOT NULL)"),
+			wantConflict: `"((lower(email))) WHERE (email IS NOT NULL)"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyIndex := util.NewIndex()
+			got := NewTemplateUniqueKey(entity, tt.index, &keyIndex)
+			if got.Conflict != tt.wantConflict || got.HasFinder != tt.wantFinder {
+				t.Errorf("TemplateUniqueKey.Conflict, HasFinder = %v, %v, want %v, %v", got.Conflict, got.HasFinder, tt.wantConflict, tt.wantFinder)
+			}
+		})
+	}
+}
+
+func TestNewTemplateEntity_Partitioned(t *testing.T) {
+	options := Options{NoAlias: true, NoDiscard: true}
+	options.GoPgVer = 9
+
+	entity := model.NewEntity(util.PublicSchema, "events", nil, nil)
+	entity.PartitionKey = `RANGE ("createdAt")`
+
+	tmpl := NewTemplateEntity(entity, options)
+	want := "`pg:\"public.events,partitionBy:'RANGE (\\\"createdAt\\\")'\"`"
+	if string(tmpl.Tag) != want {
+		t.Errorf("TemplateEntity.Tag = %v, want %v", tmpl.Tag, want)
+	}
+}
+
+func TestNewTemplateEntity_Inherited(t *testing.T) {
+	options := Options{}
+	options.GoPgVer = 9
+
+	parent := model.NewEntity(util.PublicSchema, "audit", []model.Column{
+		model.NewColumn("at", model.TypePGTimestamptz, false, false, false, 0, false, false, 0, "", []string{}, 9),
+	}, nil)
+
+	at := model.NewColumn("at", model.TypePGTimestamptz, false, false, false, 0, false, false, 0, "", []string{}, 9)
+	at.IsInherited = true
+	userID := model.NewColumn("userId", model.TypePGInt8, false, false, false, 0, false, false, 0, "", []string{}, 9)
+
+	child := model.NewEntity(util.PublicSchema, "user_audit", []model.Column{at, userID}, nil)
+
+	t.Run("Should keep inherited columns without parent", func(t *testing.T) {
+		tmpl := NewTemplateEntity(child, options)
+		if len(tmpl.Parents) != 0 || tmpl.Columns[0].Embedded {
+			t.Errorf("TemplateEntity.Parents, Columns[0].Embedded = %v, %v, want %v, %v", tmpl.Parents, tmpl.Columns[0].Embedded, 0, false)
+		}
+	})
+
+	t.Run("Should embed parent", func(t *testing.T) {
+		child.AddParent(&parent)
+		tmpl := NewTemplateEntity(child, options)
+		if len(tmpl.Parents) != 1 || tmpl.Parents[0] != parent.GoName || !tmpl.Columns[0].Embedded || tmpl.Columns[1].Embedded {
+			t.Errorf("TemplateEntity.Parents, Columns[0].Embedded = %v, %v, want %v, %v", tmpl.Parents, tmpl.Columns[0].Embedded, parent.GoName, true)
+		}
+	})
+
+	t.Run("Should embed parent columns without relations", func(t *testing.T) {
+		parent.AddRelation(model.NewRelation([]string{"userId"}, util.PublicSchema, "users", []string{"userId"}))
+		tmpl := NewTemplateEntity(child, options)
+		if len(tmpl.Parents) != 1 || tmpl.Parents[0] != parent.GoName+"Columns" {
+			t.Errorf("TemplateEntity.Parents = %v, want %v", tmpl.Parents, parent.GoName+"Columns")
+		}
+
+		if !NewTemplateEntity(parent, options).ColumnsStruct {
+			t.Errorf("TemplateEntity.ColumnsStruct = %v, want %v", false, true)
+		}
+	})
+}
+
+func TestNewTemplateColumn_EnumArray(t *testing.T) {
+	options := Options{}
+	options.GoPgVer = 9
+
+	mood := model.NewEnum(util.PublicSchema, "mood", []string{"sad", "happy"})
+
+	tests := []struct {
+		name     string
+		nullable bool
+		dims     int
+		want     string
+	}{
+		{
+			name: "Should dive into array",
+			dims: 1,
+			want: "`pg:\"moods,array,use_zero\" json:\"moods\" form:\"moods\" query:\"moods\" validate:\"required,dive,oneof='sad' 'happy'\"`",
+		},
+		{
+			name:     "Should dive into every dimension of nullable array",
+			nullable: true,
+			dims:     2,
+			want:     "`pg:\"moods,array\" json:\"moods\" form:\"moods\" query:\"moods\" validate:\"omitempty,dive,dive,oneof='sad' 'happy'\"`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			column := model.NewColumn("moods", model.TypePGVarchar, tt.nullable, false, true, tt.dims, false, false, 0, "", []string{}, 9)
+			column.AddEnum(&mood)
+			entity := model.NewEntity(util.PublicSchema, "users", []model.Column{column}, nil)
+
+			tmpl := NewTemplateColumn(entity, entity.Columns[0], options)
+			if string(tmpl.Tag) != tt.want {
+				t.Errorf("TemplateColumn.Tag = %v, want %v", tmpl.Tag, tt.want)
+			}
+			if len(entity.Enums) != 1 {
+				t.Errorf("len(Entity.Enums) = %v, want %v", len(entity.Enums), 1)
+			}
+		})
+	}
+}
+
+func TestNewTemplateColumn_Default(t *testing.T) {
+	tests := []struct {
+		name    string
+		goPGVer int
+		pgType  string
+		def     string
+		want    string
+	}{
+		{
+			name:   "Should keep zero of bool with literal default",
+			pgType: model.TypePGBool,
+			def:    "true",
+			want:   "`pg:\"value,use_zero,default:true\" json:\"value\" form:\"value\" query:\"value\"`",
+		},
+		{
+			name:   "Should keep zero of int with literal default",
+			pgType: model.TypePGInt4,
+			def:    "5",
+			want:   "`pg:\"value,use_zero,default:5\" json:\"value\" form:\"value\" query:\"value\"`",
+		},
+		{
+			name:   "Should let database fill function default",
+			pgType: model.TypePGTimestamptz,
+			def:    "now()",
+			want:   "`pg:\"value,default:now()\" json:\"value\" form:\"value\" query:\"value\"`",
+		},
+		{
+			name:   "Should let database fill sequence default",
+			pgType: model.TypePGInt8,
+			def:    "nextval('values_seq'::regclass)",
+			want:   "`pg:\"value\" json:\"value\" form:\"value\" query:\"value\"`",
+		},
+		{
+			name:    "Should keep zero of literal default in v8",
+			goPGVer: 8,
+			pgType:  model.TypePGInt4,
+			def:     "5",
+			want:    "`sql:\"value,notnull,default:5\" json:\"value\" form:\"value\" query:\"value\"`",
+		},
+		{
+			name:    "Should let database fill function default in v8",
+			goPGVer: 8,
+			pgType:  model.TypePGTimestamptz,
+			def:     "now()",
+			want:    "`sql:\"value,default:now()\" json:\"value\" form:\"value\" query:\"value\"`",
+		},
+		{
+			name:    "Should let database fill sequence default in v8",
+			goPGVer: 8,
+			pgType:  model.TypePGInt8,
+			def:     "nextval('values_seq'::regclass)",
+			want:    "`sql:\"value\" json:\"value\" form:\"value\" query:\"value\"`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := Options{}
+			options.GoPgVer = 9
+			if tt.goPGVer != 0 {
+				options.GoPgVer = tt.goPGVer
+			}
+
+			column := model.NewColumn("value", tt.pgType, false, false, false, 0, false, false, 0, "", []string{}, options.GoPgVer)
+			column.AddDefault(tt.def)
+			entity := model.NewEntity(util.PublicSchema, "values", []model.Column{column}, nil)
+
+			tmpl := NewTemplateColumn(entity, entity.Columns[0], options)
+			if string(tmpl.Tag) != tt.want {
+				t.Errorf("TemplateColumn.Tag = %v, want %v", tmpl.Tag, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTemplateColumn_CompositeArray(t *testing.T) {
+	point := model.NewComposite(util.PublicSchema, "point2d", nil)
+	stops := model.NewColumn("stops", "point2d", true, false, true, 1, false, false, 0, "", []string{}, 9)
+	stops.AddComposite(&point)
+	entity := model.NewEntity(util.PublicSchema, "routes", []model.Column{stops}, nil)
+
+	t.Run("Should map array to composite structs", func(t *testing.T) {
+		options := Options{}
+		options.GoPgVer = 9
+
+		tmpl := NewTemplateColumn(entity, entity.Columns[0], options)
+		want := "`pg:\"stops,array\" json:\"stops\" form:\"stops\" query:\"stops\"`"
+		if string(tmpl.Tag) != want || tmpl.Type != "[]PublicPoint2d" {
+			t.Errorf("TemplateColumn.Tag, Type = %v, %v, want %v, %v", tmpl.Tag, tmpl.Type, want, "[]PublicPoint2d")
+		}
+
+		if composite := NewTemplateComposite(point, options); !composite.ArrayMethods {
+			t.Errorf("TemplateComposite.ArrayMethods = %v, want %v", composite.ArrayMethods, true)
+		}
+
+		if err := checkCompositeArrays([]model.Entity{entity}, options); err != nil {
+			t.Errorf("checkCompositeArrays() error = %v", err)
+		}
+	})
+
+	t.Run("Should fail for go-pg v8", func(t *testing.T) {
+		options := Options{}
+		options.GoPgVer = 8
+
+		if err := checkCompositeArrays([]model.Entity{entity}, options); err == nil {
+			t.Errorf("checkCompositeArrays() error = nil, want error")
+		}
+	})
+}
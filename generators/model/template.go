@@ -8,12 +8,51 @@ import ({{range .Imports}}
     "{{.}}"{{end}}
 ){{end}}
 
-{{range $model := .Entities}}
+{{range $model := .Entities}}{{if .Doc}}
+{{.Doc}}{{end}}
 type {{.GoName}} struct {
-	{{range .Columns}}
-	{{.GoName}} {{.Type}} {{.Tag}} {{.Comment}}{{end}}{{if .HasRelations}}
+	{{range .Parents}}
+	{{.}}{{end}}
+	{{range .Columns}}{{if not .Embedded}}{{if .Doc}}
+	{{.Doc}}{{end}}
+	{{.GoName}} {{.Type}} {{.Tag}} {{.Comment}}{{end}}{{end}}{{if .HasRelations}}
 	{{range .Relations}}
-	{{.GoName}} *{{.GoType}} {{.Tag}} {{.Comment}}{{end}}{{end}}
+	{{.GoName}} *{{.GoType}} {{.Tag}} {{.Comment}}{{end}}{{end}}{{if .HasInverseRelations}}
+	{{range .InverseRelations}}
+	{{.GoName}} {{if .HasMany}}[]{{end}}*{{.GoType}} {{.Tag}} {{.Comment}}{{end}}{{end}}
 }
-{{end}}
+{{if .ColumnsStruct}}
+// {{.GoName}}Columns are columns of {{.GoName}} without relations, embedded by structs of inheriting tables
+type {{.GoName}}Columns struct {
+	{{range .Parents}}
+	{{.}}{{end}}
+	{{range .Columns}}{{if not .Embedded}}{{if .Doc}}
+	{{.Doc}}{{end}}
+	{{.GoName}} {{.Type}} {{.Tag}} {{.Comment}}{{end}}{{end}}
+}
+{{end}}{{if .Materialized}}
+// Refresh refreshes materialized view {{.PGFullName}}
+func (m *{{.GoName}}) Refresh(db orm.DB) error {
+	_, err := db.Model(m).Exec("refresh materialized view ?TableName")
+	return err
+}
+{{end}}{{if .GeneratedColumns}}
+// {{.GoName}}GeneratedColumns are written by database only, postgres fails to update them
+var {{.GoName}}GeneratedColumns = []string{ {{.GeneratedColumns}} }
+
+// Update updates {{.GoName}} by primary key excluding {{.GoName}}GeneratedColumns,
+// db.Model(m).Update() should be called with Column() of writable columns only
+func (m *{{.GoName}}) Update(db orm.DB) error {
+	_, err := db.Model(m).WherePK().ExcludeColumn({{.GoName}}GeneratedColumns...).Update()
+	return err
+}
+{{end}}{{range .UniqueKeys}}{{if not $model.ReadOnly}}
+// {{$model.GoName}}Conflict{{.GoName}} is upsert conflict target for unique key {{.Name}}
+const {{$model.GoName}}Conflict{{.GoName}} = {{.Conflict}}
+{{end}}{{if .HasFinder}}
+// FindBy{{.GoName}} selects {{$model.GoName}} by unique key {{.Name}}
+func (m *{{$model.GoName}}) FindBy{{.GoName}}(db orm.DB) error {
+	return db.Model(m).Where({{.Where}}).Select()
+}
+{{end}}{{end}}{{end}}
 `
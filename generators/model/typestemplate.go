@@ -0,0 +1,27 @@
+package model
+
+const TypesTemplate = `//nolint
+//lint:file-ignore U1000 ignore unused code, it's generated
+package {{.Package}}{{if .HasCompositeImports}}
+
+import ({{range .CompositeImports}}
+    "{{.}}"{{end}}
+){{end}}
+{{range .Composites}}
+// {{.GoName}} is a composite type {{.PGFullName}}
+type {{.GoName}} struct {
+	{{range .Fields}}
+	{{.GoName}} {{.Type}} {{.Tag}}{{end}}
+}
+{{if .ArrayMethods}}
+// ScanValue scans {{.GoName}} as element of array
+func (c *{{.GoName}}) ScanValue(rd types.Reader, n int) error {
+	return pgcomposite.Scan(c, rd, n)
+}
+
+// AppendValue appends {{.GoName}} as element of array
+func (c {{.GoName}}) AppendValue(b []byte, flags int) ([]byte, error) {
+	return pgcomposite.Append(b, c, flags), nil
+}
+{{end}}{{end}}
+`
@@ -11,6 +11,8 @@ var Columns = ColumnsSt{ {{range .Entities}}
 	{{.GoName}}: Columns{{.GoName}}{ {{range .Columns}}
 		{{.GoName}}: "{{.PGName}}",{{end}}{{if .HasRelations}}
 		{{range .Relations}}
+		{{.GoName}}: "{{.GoName}}",{{end}}{{end}}{{if .HasInverseRelations}}
+		{{range .InverseRelations}}
 		{{.GoName}}: "{{.GoName}}",{{end}}{{end}}
 	},{{end}}
 }
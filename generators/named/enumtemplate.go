@@ -5,6 +5,6 @@ const EnumTemplate = `//nolint
 package constant{{if .HasEnums}}
 
 const ({{range .Enums}}{{range .Entries}}
-	{{.TagName}} = "{{.Value}}"{{end}}{{end}}
+	{{.GoName}} = "{{.Value}}"{{end}}{{end}}
 ){{end}}
 `
@@ -27,16 +27,15 @@ func New() *Generator {
 // Generate runs whole generation process
 func (g *Generator) Generate() error {
 	options := g.Options()
-	return base.NewGenerator(options.URL).
-		GenerateToFiles(
-			options.Tables,
-			options.FollowFKs,
-			options.UseSQLNulls,
-			options.Output,
-			EnumTemplate,
-			BaseTemplate,
-			Template,
-			g.Packer(),
-			options.GoPgVer,
-		)
+
+	read := options.ReadOptions()
+	read.UseSQLNulls = options.UseSQLNulls
+
+	return base.NewGenerator(options.URL, options.Snapshot, options.Timeout).
+		GenerateToFiles(read, options.Output, base.Templates{
+			Enum:  EnumTemplate,
+			Types: TypesTemplate,
+			Base:  BaseTemplate,
+			Model: Template,
+		}, g.Packer())
 }
@@ -63,8 +63,7 @@ func (g *Search) AddFlags(command *cobra.Command) {
 func (g *Search) ReadFlags(command *cobra.Command) error {
 	var err error
 
-	g.options.URL, g.options.Output, g.options.Tables, g.options.FollowFKs, err = base.ReadFlags(command)
-	if err != nil {
+	if err = base.ReadFlags(command, &g.options.Options); err != nil {
 		return err
 	}
 
@@ -98,32 +97,16 @@ func (g *Search) ReadFlags(command *cobra.Command) error {
 
 // Generate runs whole generation process
 func (g *Search) Generate() error {
-	return base.NewGenerator(g.options.URL).
-		Generate(
-			g.options.Tables,
-			g.options.FollowFKs,
-			false,
-			g.options.Output,
-			EnumTemplate,
-			Template,
-			g.Packer(),
-			g.options.GoPgVer,
-		)
+	return g.Repack(g.Packer())
 }
 
 // Repack runs generator with custom packer
 func (g *Search) Repack(packer base.Packer) error {
-	return base.NewGenerator(g.options.URL).
-		Generate(
-			g.options.Tables,
-			g.options.FollowFKs,
-			false,
-			g.options.Output,
-			EnumTemplate,
-			Template,
-			packer,
-			g.options.GoPgVer,
-		)
+	return base.NewGenerator(g.options.URL, g.options.Snapshot, g.options.Timeout).
+		Generate(g.options.ReadOptions(), g.options.Output, base.Templates{
+			Enum:  EnumTemplate,
+			Model: Template,
+		}, packer)
 }
 
 // Packer returns packer function for compile entities into package
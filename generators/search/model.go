@@ -66,6 +66,8 @@ func NewTemplatePackage(entities []model.Entity, options Options) TemplatePackag
 type TemplateEntity struct {
 	model.Entity
 
+	Doc template.HTML
+
 	NoAlias bool
 	Alias   string
 
@@ -84,19 +86,43 @@ func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
 
 	var columns []TemplateColumn
 	for _, column := range entity.Columns {
-		if column.IsArray || column.GoType == model.TypeMapInterface || column.GoType == model.TypeMapString {
+		if column.IsArray || column.Composite != nil || column.GoType == model.TypeMapInterface || column.GoType == model.TypeMapString {
 			continue
 		}
 
-		columns = append(columns, NewTemplateColumn(entity, column, options))
+		tmpl := NewTemplateColumn(entity, column, options)
+		if column.PGType == model.TypePGCitext {
+			// value is cast to citext, so it is compared case-insensitively
+			tmpl.UseCustomRender = true
+			tmpl.CustomRender = condition(entity, tmpl.GoName, tmpl.GoName, "=", model.TypePGCitext, options)
+		}
+
+		columns = append(columns, tmpl)
 		if column.Import != "" {
 			imports.Add(column.Import)
 		}
+
+		var filters []TemplateColumn
+		switch {
+		case model.IsRange(column.PGType):
+			filters = rangeFilters(entity, column, options)
+		case column.PGType == model.TypePGLtree:
+			filters = ltreeFilters(entity, column, options)
+		}
+
+		for _, filter := range filters {
+			if filter.GoType == model.TypeTime && !options.Relaxed {
+				imports.Add("time")
+			}
+		}
+		columns = append(columns, filters...)
 	}
 
 	return TemplateEntity{
 		Entity: entity,
 
+		Doc: template.HTML(util.Comment(entity.Description)),
+
 		NoAlias: options.NoAlias,
 		Alias:   util.DefaultAlias,
 
@@ -111,6 +137,8 @@ type TemplateColumn struct {
 
 	Relaxed bool
 
+	Doc template.HTML
+
 	UseCustomRender bool
 	CustomRender    template.HTML
 }
@@ -130,5 +158,67 @@ func NewTemplateColumn(_ model.Entity, column model.Column, options Options) Tem
 	return TemplateColumn{
 		Relaxed: options.Relaxed,
 		Column:  column,
+
+		Doc: template.HTML(util.Comment(column.Description)),
+	}
+}
+
+// rangeFilters creates overlap and contains filters for range column
+func rangeFilters(entity model.Entity, column model.Column, options Options) []TemplateColumn {
+	// multiranges are filtered by single range
+	rangeType, _ := model.GoType(model.RangeOf(column.PGType))
+	elementType, _ := model.GoType(model.RangeElement(column.PGType))
+
+	return []TemplateColumn{
+		filter(entity, column, "Overlaps", "overlaps given range", rangeType, "&&", model.RangeOf(column.PGType), options),
+		filter(entity, column, "Contains", "contains given value", elementType, "@>", model.RangeElement(column.PGType), options),
 	}
 }
+
+// ltreeFilters creates ancestor and descendant filters for ltree column
+func ltreeFilters(entity model.Entity, column model.Column, options Options) []TemplateColumn {
+	return []TemplateColumn{
+		filter(entity, column, "AncestorOf", "is ancestor of given path or equal to it", model.TypeLtree, "@>", model.TypePGLtree, options),
+		filter(entity, column, "DescendantOf", "is descendant of given path or equal to it", model.TypeLtree, "<@", model.TypePGLtree, options),
+	}
+}
+
+// filter creates additional filter for column with custom condition
+func filter(entity model.Entity, column model.Column, suffix, doc, goType, operator, cast string, options Options) TemplateColumn {
+	// column GoName is used in Columns struct
+	if !options.KeepPK && column.IsPK {
+		column.GoName = util.ID
+	}
+
+	field := column
+	field.GoName = column.GoName + suffix
+	field.GoType = goType
+	field.Description = fmt.Sprintf("%s filters rows where %s %s", field.GoName, column.PGName, doc)
+	// filter fields are not primary keys
+	field.IsPK = false
+
+	tmpl := NewTemplateColumn(entity, field, options)
+	if !options.Relaxed && goType == model.TypeTime {
+		// time is already a pointer
+		tmpl.Type = model.TypeTime
+	}
+
+	tmpl.UseCustomRender = true
+	tmpl.CustomRender = condition(entity, column.GoName, field.GoName, operator, cast, options)
+
+	return tmpl
+}
+
+// condition renders where condition comparing column with filter value cast to pg type
+func condition(entity model.Entity, column, field, operator, cast string, options Options) template.HTML {
+	ident, table := "pg.F", "Name"
+	if options.GoPgVer == 9 {
+		ident = "pg.Ident"
+	}
+	if !options.NoAlias {
+		table = "Alias"
+	}
+
+	return template.HTML(fmt.Sprintf(`query.Where("?.? %s ?::%s", %s(Tables.%s.%s), %s(Columns.%s.%s), s.%s)`,
+		operator, cast, ident, entity.GoName, table, ident, entity.GoName, column, field))
+}
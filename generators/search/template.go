@@ -56,11 +56,13 @@ type Searcher interface {
 	WithApply(a applier)
 }
 
-{{range $model := .Entities}}
+{{range $model := .Entities}}{{if .Doc}}
+{{.Doc}}{{end}}
 type {{.GoName}}Search struct {
 	search 
 
-	{{range .Columns}}
+	{{range .Columns}}{{if .Doc}}
+	{{.Doc}}{{end}}
 	{{.GoName}} {{.Type}}{{end}}
 }
 
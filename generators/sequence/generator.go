@@ -0,0 +1,94 @@
+package sequence
+
+import (
+	"fmt"
+
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	pkg  = "pkg"
+	gopg = "gopg"
+)
+
+// CreateCommand creates generator command
+func CreateCommand() *cobra.Command {
+	return base.CreateCommand("sequence", "Sequence helpers generator for go-pg", New())
+}
+
+// Sequence represents sequence helpers generator
+type Sequence struct {
+	options Options
+}
+
+// New creates generator
+func New() *Sequence {
+	return &Sequence{}
+}
+
+// Options gets options
+func (g *Sequence) Options() *Options {
+	return &g.options
+}
+
+// SetOptions sets options
+func (g *Sequence) SetOptions(options Options) {
+	g.options = options
+}
+
+// AddFlags adds flags to command
+func (g *Sequence) AddFlags(command *cobra.Command) {
+	base.AddFlags(command)
+
+	flags := command.Flags()
+	flags.SortFlags = false
+
+	flags.StringP(pkg, "p", util.DefaultPackage, "package for sequences file")
+
+	flags.IntP(gopg, "g", 9, "specify go-pg version (8 and 9 supported)\n")
+}
+
+// ReadFlags read flags from command
+func (g *Sequence) ReadFlags(command *cobra.Command) error {
+	var err error
+
+	if err = base.ReadFlags(command, &g.options.Options); err != nil {
+		return err
+	}
+
+	flags := command.Flags()
+
+	if g.options.Package, err = flags.GetString(pkg); err != nil {
+		return err
+	}
+
+	if g.options.GoPgVer, err = flags.GetInt(gopg); err != nil {
+		return err
+	}
+
+	if g.options.GoPgVer != 8 && g.options.GoPgVer != 9 {
+		return fmt.Errorf("version %d not supported", g.options.GoPgVer)
+	}
+
+	// setting defaults
+	g.options.Def()
+
+	return nil
+}
+
+// Generate runs whole generation process
+func (g *Sequence) Generate() error {
+	return base.NewGenerator(g.options.URL, g.options.Snapshot, g.options.Timeout).
+		GenerateSequences(g.options.ReadOptions(), g.options.Output, Template, g.Packer())
+}
+
+// Packer returns packer function for compile sequences into package
+func (g *Sequence) Packer() base.SequencePacker {
+	return func(sequences []model.Sequence) (interface{}, error) {
+		return NewTemplatePackage(sequences, g.options), nil
+	}
+}
@@ -0,0 +1,77 @@
+package sequence
+
+import (
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+)
+
+// TemplatePackage stores package info
+type TemplatePackage struct {
+	Package string
+
+	GoPGVer string
+
+	Sequences []TemplateSequence
+}
+
+// NewTemplatePackage creates a package for template
+func NewTemplatePackage(sequences []model.Sequence, options Options) TemplatePackage {
+	models := make([]TemplateSequence, len(sequences))
+	for i, sequence := range sequences {
+		models[i] = NewTemplateSequence(sequence)
+	}
+
+	goPGVer := ""
+	if options.GoPgVer == 9 {
+		goPGVer = "/v9"
+	}
+
+	return TemplatePackage{
+		Package: options.Package,
+
+		GoPGVer: goPGVer,
+
+		Sequences: models,
+	}
+}
+
+// TemplateSequence stores sequence info
+type TemplateSequence struct {
+	model.Sequence
+
+	// Doc is a comment of Next function, SetDoc of Set function
+	Doc    template.HTML
+	SetDoc template.HTML
+
+	// Name is go literal with quoted sequence name passed to nextval and setval
+	Name template.HTML
+}
+
+// NewTemplateSequence creates a sequence for template
+func NewTemplateSequence(sequence model.Sequence) TemplateSequence {
+	doc := fmt.Sprintf("// Next%sID gets next value of %s", sequence.GoName, sequence.PGFullName)
+	if sequence.IsOwned() {
+		doc += fmt.Sprintf(", owned by %s.%s", util.Join(sequence.OwnerSchema, sequence.OwnerTable), sequence.OwnerColumn)
+	}
+	if sequence.Description != "" {
+		doc += "\n//\n" + util.Comment(sequence.Description)
+	}
+
+	return TemplateSequence{
+		Sequence: sequence,
+
+		Doc:    template.HTML(doc),
+		SetDoc: template.HTML(fmt.Sprintf("// Set%sID sets current value of %s, next value got is v plus increment", sequence.GoName, sequence.PGFullName)),
+		Name:   template.HTML(strconv.Quote(quote(sequence.PGSchema) + "." + quote(sequence.PGName))),
+	}
+}
+
+// quote quotes pg identifier
+func quote(s string) string {
+	return `"` + strings.Replace(s, `"`, `""`, -1) + `"`
+}
@@ -0,0 +1,46 @@
+package sequence
+
+import (
+	"testing"
+
+	"github.com/dizzyfool/genna/model"
+)
+
+func TestNewTemplateSequence(t *testing.T) {
+	tests := []struct {
+		name     string
+		sequence func() model.Sequence
+		wantDoc  string
+		wantName string
+	}{
+		{
+			name: "Should document owner",
+			sequence: func() model.Sequence {
+				s := model.NewSequence("public", "users_id_seq", model.TypePGInt4)
+				s.AddOwner("public", "users", "id")
+				return s
+			},
+			wantDoc:  "// NextPublicUsersID gets next value of public.users_id_seq, owned by public.users.id",
+			wantName: `"\"public\".\"users_id_seq\""`,
+		},
+		{
+			name: "Should quote name",
+			sequence: func() model.Sequence {
+				return model.NewSequence("billing", "Invoice", model.TypePGInt8)
+			},
+			wantDoc:  "// NextBillingInvoiceID gets next value of billing.Invoice",
+			wantName: `"\"billing\".\"Invoice\""`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewTemplateSequence(tt.sequence())
+			if string(got.Doc) != tt.wantDoc {
+				t.Errorf("NewTemplateSequence() Doc = %v, want %v", got.Doc, tt.wantDoc)
+			}
+			if string(got.Name) != tt.wantName {
+				t.Errorf("NewTemplateSequence() Name = %v, want %v", got.Name, tt.wantName)
+			}
+		})
+	}
+}
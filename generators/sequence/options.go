@@ -0,0 +1,25 @@
+package sequence
+
+import (
+	"strings"
+
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/util"
+)
+
+// Options for generator
+type Options struct {
+	base.Options
+
+	// Package sets package name for sequences
+	Package string
+}
+
+// Def fills default values of an options
+func (o *Options) Def() {
+	o.Options.Def()
+
+	if strings.Trim(o.Package, " ") == "" {
+		o.Package = util.DefaultPackage
+	}
+}
@@ -0,0 +1,24 @@
+package sequence
+
+const Template = `//nolint
+//lint:file-ignore U1000 ignore unused code, it's generated
+package {{.Package}}
+{{if .Sequences}}
+import (
+	"github.com/go-pg/pg{{.GoPGVer}}"
+	"github.com/go-pg/pg{{.GoPGVer}}/orm"
+)
+{{end}}{{range .Sequences}}
+{{.Doc}}
+func Next{{.GoName}}ID(db orm.DB) ({{.GoType}}, error) {
+	var id {{.GoType}}
+	_, err := db.QueryOne(pg.Scan(&id), "select nextval(?)", {{.Name}})
+	return id, err
+}
+
+{{.SetDoc}}
+func Set{{.GoName}}ID(db orm.DB, v {{.GoType}}) error {
+	_, err := db.Exec("select setval(?, ?)", {{.Name}}, v)
+	return err
+}
+{{end}}`
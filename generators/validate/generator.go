@@ -54,8 +54,7 @@ func (g *Validate) AddFlags(command *cobra.Command) {
 func (g *Validate) ReadFlags(command *cobra.Command) error {
 	var err error
 
-	g.options.URL, g.options.Output, g.options.Tables, g.options.FollowFKs, err = base.ReadFlags(command)
-	if err != nil {
+	if err = base.ReadFlags(command, &g.options.Options); err != nil {
 		return err
 	}
 
@@ -77,17 +76,11 @@ func (g *Validate) ReadFlags(command *cobra.Command) error {
 
 // Generate runs whole generation process
 func (g *Validate) Generate() error {
-	return base.NewGenerator(g.options.URL).
-		Generate(
-			g.options.Tables,
-			g.options.FollowFKs,
-			false,
-			g.options.Output,
-			EnumTemplate,
-			Template,
-			g.Packer(),
-			g.options.GoPgVer,
-		)
+	return base.NewGenerator(g.options.URL, g.options.Snapshot, g.options.Timeout).
+		Generate(g.options.ReadOptions(), g.options.Output, base.Templates{
+			Enum:  EnumTemplate,
+			Model: Template,
+		}, g.Packer())
 }
 
 // Packer returns packer function for compile entities into package
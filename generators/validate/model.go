@@ -3,6 +3,7 @@ package validate
 import (
 	"fmt"
 	"html/template"
+	"strconv"
 	"strings"
 
 	"github.com/dizzyfool/genna/model"
@@ -42,6 +43,11 @@ func NewTemplatePackage(entities []model.Entity, options Options) TemplatePackag
 
 	var models []TemplateEntity
 	for _, entity := range entities {
+		// views are never written
+		if entity.ReadOnly {
+			continue
+		}
+
 		mdl := NewTemplateEntity(entity, options)
 		if len(mdl.Columns) == 0 {
 			continue
@@ -68,6 +74,8 @@ func NewTemplatePackage(entities []model.Entity, options Options) TemplatePackag
 type TemplateEntity struct {
 	model.Entity
 
+	Doc template.HTML
+
 	Columns []TemplateColumn
 	Imports []string
 }
@@ -89,14 +97,16 @@ func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
 		tmpl := NewTemplateColumn(column, options)
 
 		columns = append(columns, tmpl)
-		if tmpl.Import != "" {
-			imports.Add(tmpl.Import)
+		for _, imp := range tmpl.Imports {
+			imports.Add(imp)
 		}
 	}
 
 	return TemplateEntity{
 		Entity: entity,
 
+		Doc: template.HTML(util.Comment(entity.Description)),
+
 		Columns: columns,
 		Imports: imports.Elements(),
 	}
@@ -108,8 +118,22 @@ type TemplateColumn struct {
 
 	Check string
 	Enum  template.HTML
+	Doc   template.HTML
+	Rules []TemplateRule
 
-	Import string
+	// Range, Element and RangeEnd are set for arrays of enums
+	// Range opens loops over all dimensions, Element is a variable of innermost loop
+	Range    template.HTML
+	Element  template.HTML
+	RangeEnd template.HTML
+
+	Imports []string
+}
+
+// TemplateRule stores check constraint condition, error is set if condition is true
+type TemplateRule struct {
+	Condition template.HTML
+	Error     string
 }
 
 // NewTemplateColumn creates a column for template
@@ -122,23 +146,70 @@ func NewTemplateColumn(column model.Column, options Options) TemplateColumn {
 		Column: column,
 
 		Check: check(column),
+		Doc:   template.HTML(util.Comment(column.Description)),
+		Rules: rules(column),
 	}
 
 	if len(column.Values) > 0 {
-		tmpl.Enum = template.HTML(fmt.Sprintf(`"%s"`, strings.Join(column.Values, `", "`)))
+		values := column.Values
+		// empty string is stored as null for non pointer columns
+		if tmpl.Check == Enum && column.Nullable {
+			values = append([]string{""}, values...)
+		}
+		tmpl.Enum = template.HTML(fmt.Sprintf(`"%s"`, strings.Join(values, `", "`)))
 	}
 
+	if len(column.Values) > 0 && column.IsArray {
+		element := "m." + column.GoName
+		loops := make([]string, column.Dimensions)
+		for i := range loops {
+			loops[i] = fmt.Sprintf("for _, v%d := range %s {", i, element)
+			element = fmt.Sprintf("v%d", i)
+		}
+
+		tmpl.Range = template.HTML(strings.Join(loops, "\n"))
+		tmpl.Element = template.HTML(element)
+		tmpl.RangeEnd = template.HTML(strings.Repeat("}", column.Dimensions))
+	}
+
+	imports := util.NewSet()
 	if tmpl.Check == PLen || tmpl.Check == Len {
-		tmpl.Import = "unicode/utf8"
+		imports.Add("unicode/utf8")
+	}
+
+	for _, rule := range column.Checks {
+		if rule.Kind == model.CheckLength && applies(rule, column) {
+			imports.Add("unicode/utf8")
+		}
+	}
+
+	for _, rule := range column.Checks {
+		if (rule.Kind == model.CheckCompare || rule.Kind == model.CheckIn) && column.GoType == model.TypeDecimal && applies(rule, column) {
+			imports.Add(model.DecimalImport)
+		}
+	}
+
+	if hasPrecision(column) {
+		if column.GoType == model.TypeDecimal {
+			imports.Add(model.DecimalImport)
+		} else {
+			imports.Add("math")
+		}
 	}
+	tmpl.Imports = imports.Elements()
 
 	return tmpl
 }
 
 // isValidatable checks if field can be validated
 func isValidatable(c model.Column) bool {
-	// validate FK
-	if c.IsFK {
+	// database managed columns are never written
+	if c.IsManaged() {
+		return false
+	}
+
+	// validate FK, unless database fills it
+	if c.IsFK && !c.HasDefault() {
 		return true
 	}
 
@@ -157,20 +228,57 @@ func isValidatable(c model.Column) bool {
 		return true
 	}
 
+	// validate check constraints
+	for _, rule := range c.Checks {
+		if applies(rule, c) {
+			return true
+		}
+	}
+
+	// validate numeric precision
+	if hasPrecision(c) {
+		return true
+	}
+
+	// validate range bounds
+	if isRange(c) {
+		return true
+	}
+
 	return false
 }
 
+// hasPrecision checks if numeric value could overflow column precision
+func hasPrecision(c model.Column) bool {
+	if c.Precision == 0 || c.IsArray || c.PGType != model.TypePGNumeric {
+		return false
+	}
+
+	// sql.Null... types are not validated
+	return c.GoType == model.TypeFloat64 && c.Type == c.GoType ||
+		c.GoType == model.TypeDecimal && (c.Type == c.GoType || c.Type == "*"+c.GoType)
+}
+
+// isRange checks if column is range or multirange with bounds to check
+func isRange(c model.Column) bool {
+	return !c.IsArray && model.IsRange(c.PGType)
+}
+
 // check return check type for validation
 func check(c model.Column) string {
 	if !isValidatable(c) {
 		return ""
 	}
 
+	// nullable arrays are not checked, elements of enum arrays are checked anyway
 	if c.IsArray || c.GoType == model.TypeMapInterface || c.GoType == model.TypeMapString {
+		if c.Nullable {
+			return ""
+		}
 		return Nil
 	}
 
-	if c.IsFK {
+	if c.IsFK && !c.HasDefault() {
 		if c.Nullable {
 			return PZero
 		}
@@ -185,7 +293,7 @@ func check(c model.Column) string {
 	}
 
 	if len(c.Values) > 0 {
-		if c.Nullable {
+		if c.Nullable && strings.HasPrefix(c.Type, "*") {
 			return PEnum
 		}
 		return Enum
@@ -193,3 +301,180 @@ func check(c model.Column) string {
 
 	return ""
 }
+
+// negated operators used to build error conditions
+var negated = map[string]string{
+	"=":  "!=",
+	"<>": "==",
+	"<":  ">=",
+	"<=": ">",
+	">":  "<=",
+	">=": "<",
+}
+
+// rules builds error conditions from check constraints
+func rules(c model.Column) []TemplateRule {
+	field := "m." + c.GoName
+	pointer := strings.HasPrefix(c.Type, "*")
+	exact := c.GoType == model.TypeDecimal
+
+	// nullable columns are checked only if set
+	value, guard := field, ""
+	empty, set := null(c)
+	if pointer && !exact {
+		// decimal methods are called on pointers too
+		value = "*" + field
+	} else if inner, ok := nullValues[c.Type]; ok {
+		value = field + inner
+	}
+	if (c.Nullable || pointer) && set != "" {
+		guard = set + " && "
+	}
+
+	var result []TemplateRule
+	for _, rule := range c.Checks {
+		if !applies(rule, c) {
+			continue
+		}
+
+		switch rule.Kind {
+		case model.CheckNotNull:
+			result = append(result, TemplateRule{Condition: template.HTML(empty), Error: "ErrEmptyValue"})
+		case model.CheckCompare:
+			condition := compare(value, rule.Operator, rule.Value, rule.IsString, exact)
+			result = append(result, TemplateRule{Condition: template.HTML(guard + condition), Error: "ErrWrongValue"})
+		case model.CheckLength:
+			condition := fmt.Sprintf("utf8.RuneCountInString(%s) %s %s", value, negated[rule.Operator], rule.Value)
+			result = append(result, TemplateRule{Condition: template.HTML(guard + condition), Error: "ErrMaxLength"})
+		case model.CheckIn:
+			conditions := make([]string, len(rule.Values))
+			for i, v := range rule.Values {
+				conditions[i] = compare(value, "=", v, rule.IsString, exact)
+			}
+			result = append(result, TemplateRule{Condition: template.HTML(guard + strings.Join(conditions, " && ")), Error: "ErrWrongValue"})
+		}
+	}
+
+	if hasPrecision(c) {
+		result = append(result, overflow(c, guard)...)
+	}
+
+	// zero range is null and is valid
+	if isRange(c) {
+		result = append(result, TemplateRule{Condition: template.HTML(fmt.Sprintf("!%s.IsValid()", field)), Error: "ErrWrongValue"})
+	}
+
+	return result
+}
+
+// overflow builds error conditions for values not fitting numeric(precision, scale)
+func overflow(c model.Column, guard string) []TemplateRule {
+	field := "m." + c.GoName
+	digits := c.Precision - c.Scale
+
+	if c.GoType == model.TypeFloat64 {
+		// float can not hold exact fraction, so only integer digits are checked
+		condition := fmt.Sprintf("math.Abs(%s) >= 1e%d", field, digits)
+		return []TemplateRule{{Condition: template.HTML(guard + condition), Error: "ErrWrongValue"}}
+	}
+
+	// decimal methods are called on pointers too
+	return []TemplateRule{
+		{
+			Condition: template.HTML(guard + fmt.Sprintf("%s.Abs().GreaterThanOrEqual(decimal.New(1, %d))", field, digits)),
+			Error:     "ErrWrongValue",
+		},
+		{
+			Condition: template.HTML(guard + fmt.Sprintf("!%s.Equal(%s.Truncate(%d))", field, field, c.Scale)),
+			Error:     "ErrWrongValue",
+		},
+	}
+}
+
+// nullValues are fields holding value of sql.Null... types
+var nullValues = map[string]string{
+	"sql.NullInt64":   ".Int64",
+	"sql.NullFloat64": ".Float64",
+	"sql.NullString":  ".String",
+	"sql.NullBool":    ".Bool",
+
+	model.TypeNullDecimal: ".Decimal",
+}
+
+// applies checks if rule can be validated for column
+// not null is checked only for nullable columns having value stored as null
+func applies(rule model.CheckRule, c model.Column) bool {
+	if !rule.AppliesTo(c) {
+		return false
+	}
+
+	if rule.Kind == model.CheckNotNull {
+		empty, _ := null(c)
+		return c.Nullable && empty != ""
+	}
+
+	return true
+}
+
+// null returns conditions of field being stored as null and being set
+// conditions are empty if every value of field is stored as is
+func null(c model.Column) (empty, set string) {
+	field := "m." + c.GoName
+
+	switch {
+	case strings.HasPrefix(c.Type, "*"):
+		return field + " == nil", field + " != nil"
+	case strings.HasPrefix(c.Type, "sql.Null") || c.Type == model.TypeNullDecimal:
+		return "!" + field + ".Valid", field + ".Valid"
+	case c.Type == "pg.NullTime":
+		return field + ".IsZero()", "!" + field + ".IsZero()"
+	case c.Type != c.GoType:
+		return "", ""
+	}
+
+	switch c.GoType {
+	case model.TypeString:
+		return field + ` == ""`, field + ` != ""`
+	case model.TypeInt, model.TypeInt32, model.TypeInt64, model.TypeFloat32, model.TypeFloat64, model.TypeDuration:
+		return field + " == 0", field + " != 0"
+	case model.TypeBool:
+		return "!" + field, field
+	case model.TypeMapInterface, model.TypeMapString, model.TypeByteSlice, model.TypeIP:
+		return "len(" + field + ") == 0", "len(" + field + ") > 0"
+	case model.TypeInterface:
+		return field + " == nil", field + " != nil"
+	case model.TypeDecimal, model.TypeIntRange, model.TypeNumRange, model.TypeTimeRange,
+		model.TypeIntMultirange, model.TypeNumMultirange, model.TypeTimeMultirange,
+		model.TypeLtree, model.TypeGeometry:
+		return field + ".IsZero()", "!" + field + ".IsZero()"
+	}
+
+	return "", ""
+}
+
+// negatedDecimal are decimal methods of negated operators
+var negatedDecimal = map[string]string{
+	"=":  "!%s.Equal(%s)",
+	"<>": "%s.Equal(%s)",
+	"<":  "%s.GreaterThanOrEqual(%s)",
+	"<=": "%s.GreaterThan(%s)",
+	">":  "%s.LessThanOrEqual(%s)",
+	">=": "%s.LessThan(%s)",
+}
+
+// compare returns error condition of value not satisfying comparison with check value
+func compare(value, operator, checkValue string, str, exact bool) string {
+	if exact {
+		return fmt.Sprintf(negatedDecimal[operator], value, fmt.Sprintf("decimal.RequireFromString(%q)", checkValue))
+	}
+
+	return fmt.Sprintf("%s %s %s", value, negated[operator], literal(checkValue, str))
+}
+
+// literal returns go literal for check value
+func literal(value string, str bool) string {
+	if str {
+		return strconv.Quote(value)
+	}
+	return value
+}
@@ -0,0 +1,158 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dizzyfool/genna/model"
+)
+
+func TestNewTemplateColumn_Rules(t *testing.T) {
+	notNull := model.CheckRule{Kind: model.CheckNotNull}
+	positive := model.CheckRule{Kind: model.CheckCompare, Operator: ">", Value: "0"}
+
+	tests := []struct {
+		name      string
+		pgType    string
+		sqlNulls  bool
+		decimal   bool
+		checks    []model.CheckRule
+		want      []string
+		wantCheck bool
+	}{
+		{
+			name:      "Should check nullable bool is set",
+			pgType:    model.TypePGBool,
+			checks:    []model.CheckRule{notNull},
+			want:      []string{"!m.Value"},
+			wantCheck: true,
+		},
+		{
+			name:      "Should check nullable jsonb is set",
+			pgType:    model.TypePGJSONB,
+			checks:    []model.CheckRule{notNull},
+			want:      []string{"len(m.Value) == 0"},
+			wantCheck: true,
+		},
+		{
+			name:      "Should check nullable inet is set",
+			pgType:    model.TypePGInet,
+			checks:    []model.CheckRule{notNull},
+			want:      []string{"len(m.Value) == 0"},
+			wantCheck: true,
+		},
+		{
+			name:      "Should check nullable time pointer is set",
+			pgType:    model.TypePGTimestamptz,
+			checks:    []model.CheckRule{notNull},
+			want:      []string{"m.Value == nil"},
+			wantCheck: true,
+		},
+		{
+			name:      "Should check sql null is valid",
+			pgType:    model.TypePGBool,
+			sqlNulls:  true,
+			checks:    []model.CheckRule{notNull},
+			want:      []string{"!m.Value.Valid"},
+			wantCheck: true,
+		},
+		{
+			name:      "Should compare value of sql null",
+			pgType:    model.TypePGInt8,
+			sqlNulls:  true,
+			checks:    []model.CheckRule{{Kind: model.CheckCompare, Operator: ">=", Value: "0"}},
+			want:      []string{"m.Value.Valid && m.Value.Int64 < 0"},
+			wantCheck: true,
+		},
+		{
+			name:      "Should check sql null string is in values",
+			pgType:    model.TypePGText,
+			sqlNulls:  true,
+			checks:    []model.CheckRule{{Kind: model.CheckIn, Values: []string{"a", "b"}, IsString: true}},
+			want:      []string{`m.Value.Valid && m.Value.String != "a" && m.Value.String != "b"`},
+			wantCheck: true,
+		},
+		{
+			name:      "Should compare decimal",
+			pgType:    model.TypePGNumeric,
+			decimal:   true,
+			checks:    []model.CheckRule{{Kind: model.CheckCompare, Operator: ">=", Value: "0.5"}},
+			want:      []string{`m.Value != nil && m.Value.LessThan(decimal.RequireFromString("0.5"))`},
+			wantCheck: true,
+		},
+		{
+			name:      "Should check sql null decimal is in values",
+			pgType:    model.TypePGNumeric,
+			sqlNulls:  true,
+			decimal:   true,
+			checks:    []model.CheckRule{{Kind: model.CheckIn, Values: []string{"1", "2"}}},
+			want:      []string{`m.Value.Valid && !m.Value.Decimal.Equal(decimal.RequireFromString("1")) && !m.Value.Decimal.Equal(decimal.RequireFromString("2"))`},
+			wantCheck: true,
+		},
+		{
+			name:   "Should skip not null for value without null",
+			pgType: model.TypePGCidr,
+			checks: []model.CheckRule{notNull},
+		},
+		{
+			name:      "Should guard nullable number",
+			pgType:    model.TypePGInt4,
+			checks:    []model.CheckRule{positive},
+			want:      []string{"m.Value != 0 && m.Value <= 0"},
+			wantCheck: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			column := model.NewColumn("value", tt.pgType, true, tt.sqlNulls, false, 0, false, false, 0, "", []string{}, 9)
+			if tt.decimal {
+				column.UseDecimal()
+			}
+			column.Checks = tt.checks
+
+			if got := isValidatable(column); got != tt.wantCheck {
+				t.Errorf("isValidatable() = %v, want %v", got, tt.wantCheck)
+			}
+
+			var got []string
+			for _, rule := range NewTemplateColumn(column, Options{}).Rules {
+				got = append(got, string(rule.Condition))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TemplateColumn.Rules = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTemplateColumn_Imports(t *testing.T) {
+	length := model.CheckRule{Kind: model.CheckLength, Operator: "<=", Value: "10"}
+
+	tests := []struct {
+		name   string
+		column model.Column
+		want   []string
+	}{
+		{
+			name:   "Should import utf8 once for length checks",
+			column: model.NewColumn("value", model.TypePGVarchar, false, false, false, 0, false, false, 10, "", []string{}, 9),
+			want:   []string{"unicode/utf8"},
+		},
+		{
+			name:   "Should import math for precision",
+			column: model.NewColumn("value", model.TypePGNumeric, false, false, false, 0, false, false, 0, "", []string{}, 9),
+			want:   []string{"math"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			column := tt.column
+			column.Precision = 10
+			column.Checks = []model.CheckRule{length}
+
+			if got := NewTemplateColumn(column, Options{}).Imports; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TemplateColumn.Imports = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
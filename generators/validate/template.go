@@ -14,11 +14,13 @@ const (
 	ErrWrongValue = "value"
 )
 
-{{range $model := .Entities}}
+{{range $model := .Entities}}{{if .Doc}}
+{{.Doc}}{{end}}
 func (m {{.GoName}}) Validate() (errors map[string]string, valid bool) {
 	errors = map[string]string{}
 
-	{{range .Columns}}
+	{{range $column := .Columns}}{{if .Doc}}
+	{{.Doc}}{{end}}
 	{{if eq .Check "nil" }}
 	if m.{{.GoName}} == nil {
 		errors[Columns.{{$model.GoName}}.{{.GoName}}] = ErrEmptyValue
@@ -53,6 +55,18 @@ func (m {{.GoName}}) Validate() (errors map[string]string, valid bool) {
 				errors[Columns.{{$model.GoName}}.{{.GoName}}] = ErrWrongValue
 		}
 	}
+	{{end}}{{if .Range}}
+	{{.Range}}
+		switch {{.Element}} {
+			case {{.Enum}}:
+			default:
+				errors[Columns.{{$model.GoName}}.{{.GoName}}] = ErrWrongValue
+		}
+	{{.RangeEnd}}
+	{{end}}{{range $rule := .Rules}}
+	if {{.Condition}} {
+		errors[Columns.{{$model.GoName}}.{{$column.GoName}}] = {{.Error}}
+	}
 	{{end}}
 	{{end}}
 
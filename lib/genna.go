@@ -1,12 +1,14 @@
 package genna
 
 import (
+	"context"
 	"fmt"
 	"log"
 
 	"github.com/dizzyfool/genna/model"
 	"github.com/dizzyfool/genna/util"
 
+	"github.com/go-pg/pg/v9"
 	"github.com/go-pg/pg/v9/orm"
 )
 
@@ -18,6 +20,14 @@ type Genna struct {
 	Store *store
 
 	Logger *log.Logger
+
+	// Snapshot is an id of snapshot exported by other transaction, see ExportSnapshot
+	// database is read in state of that snapshot if set
+	Snapshot string
+
+	// Sources are sources of queries, e.g. {QueryColumns: SourceInformationSchema}
+	// pg_catalog is used for queries not listed
+	Sources map[string]string
 }
 
 // New creates Genna
@@ -35,54 +45,284 @@ func (g *Genna) connect() error {
 		if g.DB, err = newDatabase(g.url, g.Logger); err != nil {
 			return fmt.Errorf("unable to connect to DB: %w", err)
 		}
+	}
 
-		g.Store = newStore(g.DB)
+	if g.Store == nil {
+		g.Store = newStore(context.Background(), g.DB, g.Sources)
 	}
 
 	return nil
 }
 
-// Read reads database and gets entities with columns and relations
-func (g *Genna) Read(selected []string, followFK bool, useSQLNulls bool, goPGVer int) ([]model.Entity, error) {
+// snapshot runs read in one repeatable read, read only transaction, so all queries see same state of database
+// snapshot is imported if Snapshot set, transaction is not started if DB is already a transaction
+// snapshot of transaction is exported, so independent reads could run in parallel
+// all queries are cancelled with ctx
+func (g *Genna) snapshot(ctx context.Context, read func(s *store) error) error {
 	if err := g.connect(); err != nil {
-		return nil, err
+		return err
+	}
+
+	db, ok := g.DB.(*pg.DB)
+	if !ok {
+		return read(newStore(ctx, g.DB, g.Sources))
 	}
 
-	tables, err := g.Store.Tables(selected)
+	tx, snapshot, err := export(ctx, db, g.Snapshot)
 	if err != nil {
-		return nil, err
+		// e.g. on standby of old server, reads are run one by one then
+		if tx, err = begin(ctx, db, g.Snapshot); err != nil {
+			return err
+		}
 	}
+	// nothing is written, so transaction is never committed
+	defer func() { _ = tx.Rollback() }()
 
-	if len(tables) == 0 {
-		return nil, fmt.Errorf("no tables found")
+	s := newStore(ctx, tx, g.Sources)
+	if snapshot != "" {
+		s.pool, s.snapshot = db, snapshot
 	}
 
-	relations, err := g.Store.Relations(tables)
+	return read(s)
+}
+
+// ExportSnapshot starts repeatable read, read only transaction and exports its snapshot, see ExportSnapshotContext
+func (g *Genna) ExportSnapshot() (*pg.Tx, string, error) {
+	return g.ExportSnapshotContext(context.Background())
+}
+
+// ExportSnapshotContext starts repeatable read, read only transaction and exports its snapshot
+// other processes could read database in that state by setting Snapshot, until returned transaction is finished
+// transaction is bound to ctx
+func (g *Genna) ExportSnapshotContext(ctx context.Context) (*pg.Tx, string, error) {
+	if err := g.connect(); err != nil {
+		return nil, "", err
+	}
+
+	db, ok := g.DB.(*pg.DB)
+	if !ok {
+		return nil, "", fmt.Errorf("snapshot can not be exported from %T", g.DB)
+	}
+
+	return export(ctx, db, g.Snapshot)
+}
+
+// export starts transaction and exports its snapshot, see begin
+func export(ctx context.Context, db *pg.DB, snapshot string) (*pg.Tx, string, error) {
+	tx, err := begin(ctx, db, snapshot)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	var exported string
+	if _, err := tx.QueryOne(pg.Scan(&exported), "select pg_export_snapshot()"); err != nil {
+		_ = tx.Rollback()
+		return nil, "", fmt.Errorf("exporting snapshot error: %w", err)
+	}
+
+	return tx, exported, nil
+}
+
+// begin starts repeatable read, read only transaction bound to ctx, snapshot exported by other transaction is imported if set
+func begin(ctx context.Context, db *pg.DB, snapshot string) (*pg.Tx, error) {
+	tx, err := db.WithContext(ctx).Begin()
+	if err != nil {
+		return nil, fmt.Errorf("starting transaction error: %w", err)
+	}
+
+	if _, err := tx.Exec("set transaction isolation level repeatable read, read only"); err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("setting transaction mode error: %w", err)
+	}
+
+	if snapshot != "" {
+		if _, err := tx.Exec("set transaction snapshot ?", snapshot); err != nil {
+			_ = tx.Rollback()
+			return nil, fmt.Errorf("importing snapshot %s error: %w", snapshot, err)
+		}
+	}
+
+	return tx, nil
+}
+
+// Result stores everything read from database
+type Result struct {
+	Entities []model.Entity
+
+	// Enums are enum types used by columns of entities, ordered by full name
+	Enums []model.Enum
+}
+
+// ReadOptions are options of reading tables, see ReadContext
+type ReadOptions struct {
+	// Selected and Excluded are table names or patterns, see util.Matcher
+	// excluded tables are never read, even if referenced by foreign keys or inherited
+	Selected []string
+	Excluded []string
+
+	// FollowFK follows foreign keys transitively, up to FollowFKDepth levels if it is positive
+	FollowFK      bool
+	FollowFKDepth int
+
+	// UseSQLNulls maps nullable columns to sql.Null types instead of pointers
+	UseSQLNulls bool
+	// UseDecimal maps numeric columns to exact decimal type
+	UseDecimal bool
+	// GoPGVer is a version of go-pg
+	GoPGVer int
+
+	// SkipJunctions does not return junction tables as entities, many2many relations are used instead
+	SkipJunctions bool
+	// ForeignTables reads foreign tables, parents of inherited tables are always read
+	ForeignTables bool
+}
+
+// Read reads database and gets entities with columns and relations, see ReadContext
+//
+// Deprecated: use ReadContext with ReadOptions, Read is kept for compatibility only
+func (g *Genna) Read(selected []string, followFK bool, useSQLNulls bool, goPGVer int) ([]model.Entity, error) {
+	result, err := g.ReadContext(context.Background(), ReadOptions{
+		Selected:    selected,
+		FollowFK:    followFK,
+		UseSQLNulls: useSQLNulls,
+		GoPGVer:     goPGVer,
+	})
+
+	return result.Entities, err
+}
+
+// ReadContext reads database and gets entities with columns and relations
+// all queries are run in one snapshot, see Snapshot, and are cancelled with ctx
+func (g *Genna) ReadContext(ctx context.Context, options ReadOptions) (Result, error) {
+	include, err := util.NewMatcher(options.Selected)
+	if err != nil {
+		return Result{}, err
+	}
+
+	exclude, err := util.NewMatcher(options.Excluded)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var result Result
+	err = g.snapshot(ctx, func(s *store) error {
+		result, err = read(s, include, exclude, options)
+		return err
+	})
+
+	return result, err
+}
+
+// read reads entities using store, see ReadContext
+func read(s *store, include, exclude util.Matcher, options ReadOptions) (Result, error) {
+	tables, err := s.Tables(include, exclude, options.ForeignTables)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if len(tables) == 0 {
+		return Result{}, fmt.Errorf("no tables found")
+	}
+
+	set := util.NewSet()
+	levels := map[string]int{}
+	for _, t := range tables {
+		set.Add(util.Join(t.Schema, t.Name))
 	}
 
-	if followFK {
-		set := util.NewSet()
-		for _, t := range tables {
-			set.Add(util.Join(t.Schema, t.Name))
+	add := func(t table, level int) bool {
+		if exclude.Match(t.Schema, t.Name) || !set.Add(util.Join(t.Schema, t.Name)) {
+			return false
+		}
+		tables = append(tables, t)
+		levels[util.Join(t.Schema, t.Name)] = level
+		return true
+	}
+
+	// relations and parents are read for every added table, so foreign keys are followed transitively
+	// selected tables are on level 0, targets of their foreign keys on level 1 and so on, parents share level of child
+	var relations []relation
+	var inherits []inheritance
+	for next := tables; len(next) > 0; {
+		var found []relation
+		var parents []inheritance
+		err := s.parallel(
+			func(s *store) (err error) {
+				found, err = s.Relations(next)
+				return
+			},
+			func(s *store) (err error) {
+				parents, err = s.Inherits(next)
+				return
+			},
+		)
+		if err != nil {
+			return Result{}, err
 		}
 
-		for _, r := range relations {
-			t := r.Target()
-			if set.Add(util.Join(t.Schema, t.Name)) {
-				tables = append(tables, t)
+		next = nil
+		for _, in := range parents {
+			if t := in.Parent(); add(t, levels[util.Join(in.Schema, in.Table)]) {
+				next = append(next, t)
+			}
+		}
+
+		for _, r := range found {
+			level := levels[util.Join(r.SourceSchema, r.SourceTable)] + 1
+			if !options.FollowFK || options.FollowFKDepth > 0 && level > options.FollowFKDepth {
+				continue
+			}
+			if t := r.Target(); add(t, level) {
+				next = append(next, t)
 			}
 		}
+
+		relations = append(relations, found...)
+		inherits = append(inherits, parents...)
 	}
 
 	tables = Sort(tables)
 
-	columns, err := g.Store.Columns(tables)
+	var columns []column
+	var indexes []tableIndex
+	var checks []check
+	var values []enum
+	var fields []compositeField
+	var installed []extension
+	err = s.parallel(
+		func(s *store) (err error) {
+			columns, err = s.Columns(tables)
+			return
+		},
+		func(s *store) (err error) {
+			indexes, err = s.Indexes(tables)
+			return
+		},
+		func(s *store) (err error) {
+			checks, err = s.Checks(tables)
+			return
+		},
+		func(s *store) (err error) {
+			values, err = s.Enums()
+			return
+		},
+		func(s *store) (err error) {
+			fields, err = s.Composites()
+			return
+		},
+		func(s *store) (err error) {
+			installed, err = s.Extensions()
+			return
+		},
+	)
 	if err != nil {
-		return nil, err
+		return Result{}, err
 	}
 
+	enums, enmIndex := buildEnums(values)
+	extensions := buildExtensions(installed)
+	composites, cmpIndex := buildComposites(fields, enums, enmIndex, extensions, options.UseSQLNulls, options.UseDecimal, options.GoPGVer)
+
 	entities := make([]model.Entity, len(tables))
 	index := map[string]int{}
 	for i, t := range tables {
@@ -90,14 +330,61 @@ func (g *Genna) Read(selected []string, followFK bool, useSQLNulls bool, goPGVer
 		entities[i] = t.Entity()
 	}
 
+	uniqueGoNames(entities)
+
 	for _, c := range columns {
 		if i, ok := index[util.Join(c.Schema, c.Table)]; ok {
-			entities[i].AddColumn(c.Column(useSQLNulls, goPGVer))
+			c.Type = extensionType(c.Type, c.TypeSchema, extensions)
+			column := c.Column(findEnum(enums, enmIndex, c.TypeSchema, c.Type), options.UseSQLNulls, options.UseDecimal, options.GoPGVer)
+			if k, ok := cmpIndex[util.Join(c.TypeSchema, c.Type)]; ok {
+				column.AddComposite(&composites[k])
+			}
+			entities[i].AddColumn(column)
+		}
+	}
+
+	for _, in := range inherits {
+		child, ok := index[util.Join(in.Schema, in.Table)]
+		if !ok {
+			continue
+		}
+		if parent, ok := index[util.Join(in.ParentSchema, in.ParentTable)]; ok {
+			entities[child].AddParent(&entities[parent])
 		}
 	}
 
+	for _, ix := range indexes {
+		if i, ok := index[util.Join(ix.Schema, ix.Table)]; ok {
+			entities[i].AddIndex(ix.Index())
+		}
+	}
+
+	for _, ch := range checks {
+		i, ok := index[util.Join(ch.Schema, ch.Table)]
+		if !ok {
+			continue
+		}
+
+		if ch.Column != "" {
+			entities[i].AddDomainCheck(ch.Column, ch.Name, ch.Definition)
+		} else {
+			entities[i].AddCheck(ch.Name, ch.Definition)
+		}
+	}
+
+	junctions := findJunctions(entities, relations)
+
 	for _, r := range relations {
+		// targets of excluded tables or beyond depth would be missing
+		target := util.Join(r.TargetSchema, r.TargetTable)
+		if _, ok := index[target]; !ok && (options.FollowFK || exclude.Match(r.TargetSchema, r.TargetTable)) {
+			continue
+		}
+
 		rel := r.Relation()
+		if i, ok := index[target]; ok {
+			rel.GoType = entities[i].GoName
+		}
 		if i, ok := index[util.Join(r.SourceSchema, r.SourceTable)]; ok {
 			entities[i].AddRelation(rel)
 		}
@@ -106,5 +393,342 @@ func (g *Genna) Read(selected []string, followFK bool, useSQLNulls bool, goPGVer
 		}
 	}
 
-	return entities, nil
+	// inverse relations are added only if both entities are read
+	for _, r := range relations {
+		source, ok := index[util.Join(r.SourceSchema, r.SourceTable)]
+		if !ok {
+			continue
+		}
+
+		if options.SkipJunctions && entities[source].Junction {
+			continue
+		}
+
+		if i, ok := index[util.Join(r.TargetSchema, r.TargetTable)]; ok {
+			rel := r.InverseRelation()
+			rel.GoType = entities[source].GoName
+			rel.AddEntity(&entities[source])
+			entities[i].AddInverseRelation(rel)
+		}
+	}
+
+	// many2many relations are added to both tables linked by junction
+	for _, pair := range junctions {
+		for k, r := range pair {
+			other := pair[1-k]
+
+			i, ok := index[util.Join(r.TargetSchema, r.TargetTable)]
+			if !ok {
+				continue
+			}
+
+			j, ok := index[util.Join(other.TargetSchema, other.TargetTable)]
+			if !ok {
+				continue
+			}
+
+			rel := model.NewMany2ManyRelation(r.SourceSchema, r.SourceTable, r.SourceColumns, other.SourceColumns, other.TargetSchema, other.TargetTable)
+			rel.GoType = entities[j].GoName
+			rel.AddEntity(&entities[j])
+			entities[i].AddInverseRelation(rel)
+		}
+	}
+
+	if !options.SkipJunctions || len(junctions) == 0 {
+		return Result{Entities: entities, Enums: usedEnums(entities, enums)}, nil
+	}
+
+	result := make([]model.Entity, 0, len(entities)-len(junctions))
+	for _, entity := range entities {
+		if !entity.Junction {
+			result = append(result, entity)
+		}
+	}
+
+	return Result{Entities: result, Enums: usedEnums(result, enums)}, nil
+}
+
+// uniqueGoNames keeps digits in go names of entities colliding without them, e.g. partitions events_2023 and events_2024
+// names colliding anyway get numeric suffix
+func uniqueGoNames(entities []model.Entity) {
+	counts := map[string]int{}
+	for _, entity := range entities {
+		counts[entity.GoName]++
+	}
+
+	for i := range entities {
+		if counts[entities[i].GoName] > 1 {
+			entities[i].KeepDigits()
+		}
+	}
+
+	names := map[string]int{}
+	for i := range entities {
+		if names[entities[i].GoName]++; names[entities[i].GoName] > 1 {
+			entities[i].GoName = fmt.Sprintf("%s%d", entities[i].GoName, names[entities[i].GoName])
+		}
+	}
+}
+
+// ReadFunctionsContext reads stored functions and procedures with arguments and results
+// Selected and Excluded are function names or patterns, see util.Matcher, options of tables are not used
+// overloaded functions get numeric suffix in go name
+func (g *Genna) ReadFunctionsContext(ctx context.Context, options ReadOptions) ([]model.Function, error) {
+	include, err := util.NewMatcher(options.Selected)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude, err := util.NewMatcher(options.Excluded)
+	if err != nil {
+		return nil, err
+	}
+
+	var args []functionArg
+	var values []enum
+	var installed []extension
+	err = g.snapshot(ctx, func(s *store) error {
+		return s.parallel(
+			func(s *store) (err error) {
+				args, err = s.Functions(include, exclude)
+				return
+			},
+			func(s *store) (err error) {
+				values, err = s.Enums()
+				return
+			},
+			func(s *store) (err error) {
+				installed, err = s.Extensions()
+				return
+			},
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	enums, enmIndex := buildEnums(values)
+
+	return buildFunctions(args, enums, enmIndex, buildExtensions(installed), options.UseSQLNulls, options.UseDecimal, options.GoPGVer), nil
+}
+
+// ReadSequencesContext reads sequences with columns owning them
+// Selected and Excluded are sequence or table names or patterns, see util.Matcher, other options are not used
+// sequences owned by selected tables are read too
+func (g *Genna) ReadSequencesContext(ctx context.Context, options ReadOptions) ([]model.Sequence, error) {
+	include, err := util.NewMatcher(options.Selected)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude, err := util.NewMatcher(options.Excluded)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sequence
+	err = g.snapshot(ctx, func(s *store) error {
+		rows, err = s.Sequences(include, exclude)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buildSequences(rows), nil
+}
+
+// buildSequences creates sequences, sequences with same go name get numeric suffix
+func buildSequences(rows []sequence) []model.Sequence {
+	sequences := make([]model.Sequence, len(rows))
+	names := map[string]int{}
+
+	for i, row := range rows {
+		sequences[i] = row.Sequence()
+		if names[sequences[i].GoName]++; names[sequences[i].GoName] > 1 {
+			sequences[i].GoName = fmt.Sprintf("%s%d", sequences[i].GoName, names[sequences[i].GoName])
+		}
+	}
+
+	return sequences
+}
+
+// buildFunctions creates functions from rows of arguments ordered by function
+func buildFunctions(args []functionArg, enums []model.Enum, enmIndex map[string]int, extensions map[string]string, useSQLNulls, useDecimal bool, goPGVer int) []model.Function {
+	var functions []model.Function
+	index := map[int64]int{}
+	names := map[string]int{}
+
+	// inputs are counted to find arguments with defaults, defaults are set for last ones
+	inputs := map[int64]int{}
+	for _, a := range args {
+		if a.IsInput() {
+			inputs[a.ID]++
+		}
+	}
+
+	seen := map[int64]int{}
+	for _, a := range args {
+		i, ok := index[a.ID]
+		if !ok {
+			function := a.Function()
+			if names[function.GoName]++; names[function.GoName] > 1 {
+				function.GoName = fmt.Sprintf("%s%d", function.GoName, names[function.GoName])
+			}
+
+			i = len(functions)
+			index[a.ID] = i
+			functions = append(functions, function)
+		}
+
+		a.Type = extensionType(a.Type, a.TypeSchema, extensions)
+		enum := findEnum(enums, enmIndex, a.TypeSchema, a.Type)
+
+		if a.IsInput() {
+			functions[i].AddArgument(model.Argument{
+				Column:     a.Column(false, enum, useSQLNulls, useDecimal, goPGVer),
+				HasDefault: seen[a.ID] >= inputs[a.ID]-a.Defaults,
+				Variadic:   a.Mode == modeVariadic,
+			})
+			seen[a.ID]++
+		}
+
+		if a.IsOutput() {
+			functions[i].AddResult(a.Column(true, enum, useSQLNulls, useDecimal, goPGVer))
+		}
+	}
+
+	return functions
+}
+
+// findJunctions marks entities which have composite primary key made of two foreign keys and nothing else
+// returns both relations of every junction found
+func findJunctions(entities []model.Entity, relations []relation) [][2]relation {
+	bySource := map[string][]relation{}
+	for _, r := range relations {
+		key := util.Join(r.SourceSchema, r.SourceTable)
+		bySource[key] = append(bySource[key], r)
+	}
+
+	var junctions [][2]relation
+	for i, entity := range entities {
+		rels := bySource[util.Join(entity.PGSchema, entity.PGName)]
+		if len(rels) != 2 || entity.ReadOnly || !entity.HasMultiplePKs() {
+			continue
+		}
+
+		fks := util.NewSet()
+		count := 0
+		for _, r := range rels {
+			for _, c := range r.SourceColumns {
+				fks.Add(c)
+				count++
+			}
+		}
+
+		// foreign keys should not overlap and should cover all columns
+		if fks.Len() != count || fks.Len() != len(entity.Columns) {
+			continue
+		}
+
+		junction := true
+		for _, column := range entity.Columns {
+			if !column.IsPK || !fks.Exists(column.PGName) {
+				junction = false
+				break
+			}
+		}
+
+		if junction {
+			entities[i].Junction = true
+			junctions = append(junctions, [2]relation{rels[0], rels[1]})
+		}
+	}
+
+	return junctions
+}
+
+// buildEnums creates enums indexed by full name
+func buildEnums(values []enum) ([]model.Enum, map[string]int) {
+	enums := make([]model.Enum, len(values))
+	index := map[string]int{}
+	for i, v := range values {
+		enums[i] = v.Enum()
+		index[enums[i].PGFullName] = i
+	}
+
+	return enums, index
+}
+
+// findEnum gets enum by type name, returns nil if type is not enum
+func findEnum(enums []model.Enum, index map[string]int, schema, typ string) *model.Enum {
+	if i, ok := index[util.Join(schema, typ)]; ok {
+		return &enums[i]
+	}
+
+	return nil
+}
+
+// usedEnums filters enums used by entities keeping their order
+func usedEnums(entities []model.Entity, enums []model.Enum) []model.Enum {
+	used := util.NewSet()
+	for _, entity := range entities {
+		for _, enm := range entity.Enums {
+			used.Add(enm.PGFullName)
+		}
+	}
+
+	result := []model.Enum{}
+	for _, enm := range enums {
+		if used.Exists(enm.PGFullName) {
+			result = append(result, enm)
+		}
+	}
+
+	return result
+}
+
+// buildExtensions creates schemas of installed extensions indexed by extension name
+func buildExtensions(values []extension) map[string]string {
+	extensions := map[string]string{}
+	for _, v := range values {
+		extensions[v.Name] = v.Schema
+	}
+
+	return extensions
+}
+
+// buildComposites creates composites from fields, nested composites are linked by pointers
+func buildComposites(fields []compositeField, enums []model.Enum, enmIndex map[string]int, extensions map[string]string, useSQLNulls, useDecimal bool, goPGVer int) ([]model.Composite, map[string]int) {
+	var composites []model.Composite
+	index := map[string]int{}
+	for _, f := range fields {
+		key := util.Join(f.Schema, f.Type)
+		i, ok := index[key]
+		if !ok {
+			i = len(composites)
+			index[key] = i
+			composites = append(composites, model.NewComposite(f.Schema, f.Type, nil))
+		}
+
+		f.FieldType = extensionType(f.FieldType, f.TypeSchema, extensions)
+		composites[i].AddField(f.Field(findEnum(enums, enmIndex, f.TypeSchema, f.FieldType), useSQLNulls, useDecimal, goPGVer))
+	}
+
+	// linking after all composites created, so pointers stay valid
+	for _, f := range fields {
+		k, ok := index[util.Join(f.TypeSchema, f.FieldType)]
+		if !ok {
+			continue
+		}
+
+		composite := &composites[index[util.Join(f.Schema, f.Type)]]
+		for j := range composite.Fields {
+			if composite.Fields[j].PGName == f.Name {
+				composite.Fields[j].AddComposite(&composites[k])
+			}
+		}
+	}
+
+	return composites, index
 }
@@ -1,9 +1,15 @@
 package genna
 
 import (
+	"context"
 	"log"
 	"os"
 	"testing"
+	"time"
+
+	"github.com/dizzyfool/genna/model"
+
+	"github.com/go-pg/pg/v9"
 )
 
 func prepareReq() (url string, logger *log.Logger) {
@@ -16,7 +22,7 @@ func prepareReq() (url string, logger *log.Logger) {
 func TestGenna_Read(t *testing.T) {
 	genna := New(prepareReq())
 
-	t.Run("Should read DB", func(t *testing.T) {
+	t.Run("Should read DB by positional options", func(t *testing.T) {
 		entities, err := genna.Read([]string{"public.*"}, true, false, 9)
 		if err != nil {
 			t.Errorf("Genna.Read error %v", err)
@@ -28,4 +34,300 @@ func TestGenna_Read(t *testing.T) {
 			return
 		}
 	})
+
+	t.Run("Should not follow foreign keys to excluded tables", func(t *testing.T) {
+		result, err := genna.ReadContext(context.Background(), ReadOptions{
+			Selected: []string{"public.*"},
+			Excluded: []string{"geo.*"},
+			FollowFK: true,
+			GoPGVer:  9,
+		})
+		if err != nil {
+			t.Errorf("Genna.ReadContext error %v", err)
+			return
+		}
+
+		if ln := len(result.Entities); ln != 2 {
+			t.Errorf("len(entities) = %v, want %v", ln, 2)
+			return
+		}
+
+		for _, entity := range result.Entities {
+			if ln := len(entity.Relations); ln != 0 {
+				t.Errorf("len(%s.Relations) = %v, want %v", entity.PGName, ln, 0)
+			}
+		}
+	})
+
+	t.Run("Should read DB in exported snapshot", func(t *testing.T) {
+		tx, snapshot, err := genna.ExportSnapshot()
+		if err != nil {
+			t.Errorf("Genna.ExportSnapshot error %v", err)
+			return
+		}
+		defer tx.Rollback()
+
+		reader := New(prepareReq())
+		reader.Snapshot = snapshot
+
+		result, err := reader.ReadContext(context.Background(), ReadOptions{
+			Selected: []string{"public.*"},
+			FollowFK: true,
+			GoPGVer:  9,
+		})
+		if err != nil {
+			t.Errorf("Genna.ReadContext error %v", err)
+			return
+		}
+
+		if ln := len(result.Entities); ln != 3 {
+			t.Errorf("len(entities) = %v, want %v", ln, 3)
+		}
+	})
+}
+
+func TestGenna_ReadContext(t *testing.T) {
+	genna := New(prepareReq())
+
+	t.Run("Should read DB with options", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		result, err := genna.ReadContext(ctx, ReadOptions{
+			Selected: []string{"public.*"},
+			FollowFK: true,
+			GoPGVer:  9,
+		})
+		if err != nil {
+			t.Errorf("Genna.ReadContext error %v", err)
+			return
+		}
+
+		if ln := len(result.Entities); ln != 3 {
+			t.Errorf("len(entities) = %v, want %v", ln, 3)
+		}
+	})
+
+	t.Run("Should not read DB with cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if _, err := genna.ReadContext(ctx, ReadOptions{Selected: []string{"public.*"}}); err == nil {
+			t.Errorf("Genna.ReadContext error = nil, want error")
+		}
+	})
+}
+
+func BenchmarkGenna_Read(b *testing.B) {
+	url, _ := prepareReq()
+
+	genna := New(url, nil)
+	if err := genna.connect(); err != nil {
+		b.Fatalf("connect error = %v", err)
+	}
+
+	dropLargeSchema(genna.Store)
+	defer dropLargeSchema(genna.Store)
+
+	if _, err := prepareLargeSchema(genna.Store); err != nil {
+		b.Fatalf("prepare schema error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		sources  map[string]string
+		parallel bool
+	}{
+		{
+			name:    "information_schema one by one",
+			sources: map[string]string{QueryColumns: SourceInformationSchema},
+		},
+		{
+			name:     "pg_catalog in parallel",
+			parallel: true,
+		},
+	}
+	for _, tt := range tests {
+		b.Run(tt.name, func(b *testing.B) {
+			reader := New(url, nil)
+			reader.Sources = tt.sources
+
+			if !tt.parallel {
+				// reads are run one by one in transaction of caller
+				tx, err := genna.DB.(*pg.DB).Begin()
+				if err != nil {
+					b.Fatalf("begin error = %v", err)
+				}
+				defer tx.Rollback()
+				reader.DB = tx
+			}
+
+			for i := 0; i < b.N; i++ {
+				if _, err := reader.ReadContext(context.Background(), ReadOptions{Selected: []string{"bench.*"}, GoPGVer: 9}); err != nil {
+					b.Fatalf("Genna.ReadContext error %v", err)
+				}
+			}
+		})
+	}
+}
+
+func Test_findJunctions(t *testing.T) {
+	pk := func(name string) model.Column {
+		return model.NewColumn(name, model.TypePGInt4, false, false, false, 0, true, true, 0, "", nil, 9)
+	}
+
+	userRoles := model.NewEntity("public", "user_roles", []model.Column{pk("userId"), pk("roleId")}, nil)
+	userLogs := model.NewEntity("public", "user_logs", []model.Column{pk("userId"), pk("roleId"), model.NewColumn("at", model.TypePGTimestamp, false, false, false, 0, false, false, 0, "", nil, 9)}, nil)
+
+	relations := []relation{
+		{SourceSchema: "public", SourceTable: "user_roles", SourceColumns: []string{"userId"}, TargetSchema: "public", TargetTable: "users"},
+		{SourceSchema: "public", SourceTable: "user_roles", SourceColumns: []string{"roleId"}, TargetSchema: "public", TargetTable: "roles"},
+		{SourceSchema: "public", SourceTable: "user_logs", SourceColumns: []string{"userId"}, TargetSchema: "public", TargetTable: "users"},
+		{SourceSchema: "public", SourceTable: "user_logs", SourceColumns: []string{"roleId"}, TargetSchema: "public", TargetTable: "roles"},
+	}
+
+	entities := []model.Entity{userRoles, userLogs}
+
+	t.Run("Should find only pure junction table", func(t *testing.T) {
+		junctions := findJunctions(entities, relations)
+		if ln := len(junctions); ln != 1 {
+			t.Errorf("len(findJunctions()) = %v, want %v", ln, 1)
+			return
+		}
+
+		if !entities[0].Junction || entities[1].Junction {
+			t.Errorf("Entity.Junction = %v, %v, want %v, %v", entities[0].Junction, entities[1].Junction, true, false)
+		}
+	})
+}
+
+func Test_uniqueGoNames(t *testing.T) {
+	entities := []model.Entity{
+		model.NewEntity("public", "events", nil, nil),
+		model.NewEntity("public", "events_2023", nil, nil),
+		model.NewEntity("public", "events_2024", nil, nil),
+		model.NewEntity("public", "users_v1", nil, nil),
+	}
+
+	uniqueGoNames(entities)
+
+	want := []string{"PublicEvent", "PublicEvent2023", "PublicEvent2024", "PublicUserV"}
+	for i, entity := range entities {
+		if entity.GoName != want[i] {
+			t.Errorf("Entity.GoName = %v, want %v", entity.GoName, want[i])
+		}
+	}
+}
+
+func Test_buildComposites(t *testing.T) {
+	fields := []compositeField{
+		{Schema: "public", Type: "address", Name: "street", FieldType: model.TypePGText, TypeSchema: "pg_catalog"},
+		{Schema: "public", Type: "address", Name: "location", FieldType: "point2d", TypeSchema: "public"},
+		{Schema: "public", Type: "point2d", Name: "x", FieldType: model.TypePGFloat8, TypeSchema: "pg_catalog"},
+		{Schema: "public", Type: "point2d", Name: "y", FieldType: model.TypePGFloat8, TypeSchema: "pg_catalog"},
+	}
+
+	composites, index := buildComposites(fields, nil, nil, nil, false, false, 9)
+	if len(composites) != 2 || len(index) != 2 {
+		t.Errorf("len(buildComposites()) = %v, want %v", len(composites), 2)
+		return
+	}
+
+	location := composites[index["public.address"]].Fields[1]
+	if location.Composite == nil || location.Composite.PGName != "point2d" || len(location.Composite.Fields) != 2 {
+		t.Errorf("Composite.Fields[1].Composite = %v, want linked point2d", location.Composite)
+	}
+}
+
+func Test_buildEnums(t *testing.T) {
+	values := []enum{
+		{Schema: "hr", Name: "mood", Values: []string{"bored"}},
+		{Schema: "public", Name: "mood", Values: []string{"sad", "happy"}},
+		{Schema: "public", Name: "status", Values: []string{"new"}},
+	}
+
+	enums, index := buildEnums(values)
+	if mood := findEnum(enums, index, "public", "mood"); mood == nil || len(mood.Values) != 2 {
+		t.Errorf("findEnum() = %v, want public.mood", mood)
+	}
+	if mood := findEnum(enums, index, "hr", "mood"); mood == nil || len(mood.Values) != 1 {
+		t.Errorf("findEnum() = %v, want hr.mood", mood)
+	}
+	if text := findEnum(enums, index, "pg_catalog", model.TypePGText); text != nil {
+		t.Errorf("findEnum() = %v, want nil", text)
+	}
+
+	column := model.NewColumn("mood", model.TypePGVarchar, false, false, false, 0, false, false, 0, "", nil, 9)
+	column.AddEnum(&enums[1])
+	entity := model.NewEntity("public", "users", []model.Column{column}, nil)
+
+	used := usedEnums([]model.Entity{entity}, enums)
+	if len(used) != 1 || used[0].PGFullName != "public.mood" {
+		t.Errorf("usedEnums() = %v, want only public.mood", used)
+	}
+}
+
+func Test_buildFunctions(t *testing.T) {
+	stats := functionArg{ID: 1, Schema: "public", FuncName: "user_stats", Kind: "f", ReturnType: model.TypePGRecord, ReturnsSet: true, Defaults: 1}
+	next := functionArg{ID: 2, Schema: "public", FuncName: "next_id", Kind: "f", ReturnType: model.TypePGInt8}
+	overload := functionArg{ID: 3, Schema: "public", FuncName: "next_id", Kind: "f", ReturnType: model.TypePGInt8}
+
+	args := []functionArg{
+		with(stats, 1, "user_id", modeIn, model.TypePGInt4),
+		with(stats, 2, "since", modeIn, model.TypePGTimestamptz),
+		with(stats, 3, "day", modeTable, model.TypePGDate),
+		with(stats, 4, "", modeTable, model.TypePGInt8),
+		with(next, 0, "next_id", modeResult, model.TypePGInt8),
+		with(overload, 1, "", modeIn, model.TypePGText),
+		with(overload, 0, "next_id", modeResult, model.TypePGInt8),
+	}
+
+	functions := buildFunctions(args, nil, nil, nil, false, false, 9)
+	if len(functions) != 3 {
+		t.Errorf("len(buildFunctions()) = %v, want %v", len(functions), 3)
+		return
+	}
+
+	f := functions[0]
+	if len(f.Args) != 2 || f.Args[0].HasDefault || !f.Args[1].HasDefault {
+		t.Errorf("Function.Args = %+v, want user_id without default and since with default", f.Args)
+	}
+	if len(f.Result) != 2 || f.Result[1].PGName != "column4" {
+		t.Errorf("Function.Result = %+v, want day and column4", f.Result)
+	}
+
+	if functions[1].GoName != "PublicNextId" || functions[2].GoName != "PublicNextId2" {
+		t.Errorf("Function.GoName = %v, %v, want PublicNextId, PublicNextId2", functions[1].GoName, functions[2].GoName)
+	}
+	if arg := functions[2].Args[0]; arg.PGName != "arg1" || arg.Type != model.TypeString {
+		t.Errorf("Function.Args[0] = %v %v, want arg1 string", arg.PGName, arg.Type)
+	}
+}
+
+func Test_buildSequences(t *testing.T) {
+	rows := []sequence{
+		{Schema: "public", Name: "users_id_seq", Type: model.TypePGInt4, OwnerSchema: "public", OwnerTable: "users", OwnerColumn: "id"},
+		{Schema: "public", Name: "users_seq", Type: model.TypePGInt8},
+	}
+
+	sequences := buildSequences(rows)
+	if len(sequences) != 2 {
+		t.Errorf("len(buildSequences()) = %v, want %v", len(sequences), 2)
+		return
+	}
+
+	if sequences[0].GoName != "PublicUsers" || sequences[1].GoName != "PublicUsers2" {
+		t.Errorf("Sequence.GoName = %v, %v, want PublicUsers, PublicUsers2", sequences[0].GoName, sequences[1].GoName)
+	}
+	if !sequences[0].IsOwned() || sequences[1].IsOwned() {
+		t.Errorf("Sequence.IsOwned() = %v, %v, want true, false", sequences[0].IsOwned(), sequences[1].IsOwned())
+	}
+	if sequences[0].GoType != model.TypeInt || sequences[1].GoType != model.TypeInt64 {
+		t.Errorf("Sequence.GoType = %v, %v, want int, int64", sequences[0].GoType, sequences[1].GoType)
+	}
+}
+
+func with(function functionArg, position int, name, mode, typ string) functionArg {
+	function.Position, function.Name, function.Mode, function.Type = position, name, mode, typ
+	return function
 }
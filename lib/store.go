@@ -1,9 +1,11 @@
 package genna
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/dizzyfool/genna/model"
 	"github.com/dizzyfool/genna/util"
@@ -18,13 +20,105 @@ func format(pattern string, values ...interface{}) string {
 	return string(formatter.FormatQuery([]byte{}, pattern, values...))
 }
 
+// relkind values of pg_class used by genna
+const (
+	kindTable       = "r"
+	kindPartitioned = "p"
+	kindView        = "v"
+	kindMatView     = "m"
+	kindForeign     = "f"
+)
+
+// sources of introspection queries, information_schema is standard but slow on large schemas
+const (
+	// SourceCatalog reads pg_catalog directly
+	SourceCatalog = "pg_catalog"
+	// SourceInformationSchema reads views of information_schema
+	SourceInformationSchema = "information_schema"
+)
+
+// queries which could be read from any source
+const (
+	// QueryColumns reads columns of tables
+	QueryColumns = "columns"
+	// QuerySequences reads sequences, pg_catalog has them since postgres 10
+	QuerySequences = "sequences"
+)
+
+// isPartition is read by name, so servers without declarative partitioning are supported
+const isPartition = "coalesce((to_jsonb(c) ->> 'relispartition')::boolean, false)"
+
+// ownedSequences selects sequences owned by columns, serial ones are auto dependent, identity ones are internal
+const ownedSequences = `
+		select s.oid                                  as sequence_oid,
+		       format('%I.%I', sn.nspname, s.relname) as sequence,
+		       n.nspname                              as table_schema,
+		       c.relname                              as table_name,
+		       a.attname                              as column_name
+		from pg_depend d
+		join pg_class s on s.oid = d.objid and s.relkind = 'S'
+		join pg_namespace sn on sn.oid = s.relnamespace
+		join pg_class c on c.oid = d.refobjid
+		join pg_namespace n on n.oid = c.relnamespace
+		join pg_attribute a on a.attrelid = c.oid and a.attnum = d.refobjsubid
+		where d.classid = 'pg_class'::regclass
+		  and d.refclassid = 'pg_class'::regclass
+		  and d.deptype in ('a', 'i')`
+
 type table struct {
 	Schema string `pg:"table_schema"`
 	Name   string `pg:"table_name"`
+	Kind   string `pg:"table_kind"`
+
+	Comment string `pg:"table_comment"`
+
+	// PartitionKey is set for partitioned tables, IsPartition for their partitions
+	PartitionKey string `pg:"partition_key"`
+	IsPartition  bool   `pg:"is_partition"`
 }
 
 func (t table) Entity() model.Entity {
-	return model.NewEntity(t.Schema, t.Name, nil, nil)
+	entity := model.NewEntity(t.Schema, t.Name, nil, nil)
+	entity.Description = t.Comment
+	entity.PartitionKey = t.PartitionKey
+
+	switch t.Kind {
+	case kindView:
+		entity.AddView(false)
+	case kindMatView:
+		entity.AddView(true)
+	case kindForeign:
+		entity.Foreign = true
+	}
+
+	return entity
+}
+
+// Selected checks if table is listed by name or matches any pattern
+// patterns do not match partitions and tables in system schemas
+func (t table) Selected(include util.Matcher) bool {
+	if include.MatchName(t.Schema, t.Name) {
+		return true
+	}
+
+	system := t.Schema == "information_schema" || strings.HasPrefix(t.Schema, "pg_")
+	return !t.IsPartition && !system && include.MatchPattern(t.Schema, t.Name)
+}
+
+type inheritance struct {
+	Schema       string `pg:"schema_name"`
+	Table        string `pg:"table_name"`
+	ParentSchema string `pg:"parent_schema"`
+	ParentTable  string `pg:"parent_table"`
+	ParentKind   string `pg:"parent_kind"`
+}
+
+func (i inheritance) Parent() table {
+	return table{
+		Schema: i.ParentSchema,
+		Name:   i.ParentTable,
+		Kind:   i.ParentKind,
+	}
 }
 
 type relation struct {
@@ -35,10 +129,22 @@ type relation struct {
 	TargetSchema  string   `pg:"target_schema"`
 	TargetTable   string   `pg:"target_table"`
 	TargetColumns []string `pg:"target_columns,array"`
+	Unique        bool     `pg:"is_unique"`
+	Primary       bool     `pg:"is_primary"`
 }
 
 func (r relation) Relation() model.Relation {
-	return model.NewRelation(r.SourceColumns, r.TargetSchema, r.TargetTable)
+	rel := model.NewRelation(r.SourceColumns, r.TargetSchema, r.TargetTable, r.TargetColumns)
+	rel.RefPK = r.Primary
+
+	return rel
+}
+
+func (r relation) InverseRelation() model.Relation {
+	rel := model.NewInverseRelation(r.SourceColumns, r.SourceSchema, r.SourceTable, r.TargetColumns, r.Unique)
+	rel.RefPK = r.Primary
+
+	return rel
 }
 
 func (r relation) Target() table {
@@ -51,83 +157,379 @@ func (r relation) Target() table {
 type column struct {
 	tableName struct{} `pg:",discard_unknown_columns"`
 
-	Schema     string   `pg:"schema_name"`
-	Table      string   `pg:"table_name"`
-	Name       string   `pg:"column_name"`
-	IsNullable bool     `pg:"nullable"`
-	IsArray    bool     `pg:"array"`
-	Dimensions int      `pg:"dims"`
-	Type       string   `pg:"type"`
-	Default    string   `pg:"def"`
-	IsPK       bool     `pg:"pk"`
-	IsFK       bool     `pg:"fk"`
-	MaxLen     int      `pg:"len"`
-	EnumType   string   `pg:"enumtype"`
-	Values     []string `pg:"enum,array"`
-}
-
-type enumDataType struct {
-	tableName struct{} `pg:",discard_unknown_columns"`
+	Schema     string `pg:"schema_name"`
+	Table      string `pg:"table_name"`
+	Name       string `pg:"column_name"`
+	IsNullable bool   `pg:"nullable"`
+	IsArray    bool   `pg:"array"`
+	Dimensions int    `pg:"dims"`
+	Type       string `pg:"type"`
+	Default    string `pg:"def"`
+	IsPK       bool   `pg:"pk"`
+	IsFK       bool   `pg:"fk"`
+	MaxLen     int    `pg:"len"`
+	Precision  int    `pg:"precision"`
+	Scale      int    `pg:"scale"`
+	Comment    string `pg:"comment"`
+	Domain     string `pg:"domain"`
+	TypeSchema string `pg:"type_schema"`
+	Identity   string `pg:"identity"`
+	Generated  bool   `pg:"generated"`
+	Sequence   string `pg:"sequence"`
+	Inherited  bool   `pg:"inherited"`
+}
+
+type tableIndex struct {
+	Schema      string   `pg:"schema_name"`
+	Table       string   `pg:"table_name"`
+	Name        string   `pg:"index_name"`
+	Columns     []string `pg:"columns,array"`
+	Unique      bool     `pg:"is_unique"`
+	Primary     bool     `pg:"is_primary"`
+	Constraint  bool     `pg:"is_constraint"`
+	Expressions bool     `pg:"has_expressions"`
+	Predicate   string   `pg:"predicate"`
+}
+
+func (i tableIndex) Index() model.Index {
+	return model.NewIndex(i.Name, i.Columns, i.Unique, i.Primary, i.Constraint, i.Expressions, i.Predicate)
+}
+
+type compositeField struct {
+	Schema     string `pg:"schema_name"`
+	Type       string `pg:"type_name"`
+	Name       string `pg:"field_name"`
+	FieldType  string `pg:"field_type"`
+	TypeSchema string `pg:"field_type_schema"`
+	IsArray    bool   `pg:"array"`
+	Dimensions int    `pg:"dims"`
+	MaxLen     int    `pg:"len"`
+}
+
+func (f compositeField) Field(enum *model.Enum, useSQLNulls, useDecimal bool, goPGVer int) model.Column {
+	// composite attributes can not be declared as not null
+	field := model.NewColumn(f.Name, enumType(f.FieldType, enum), true, useSQLNulls, f.IsArray, f.Dimensions, false, false, f.MaxLen, "", []string{}, goPGVer)
+	if useDecimal {
+		field.UseDecimal()
+	}
+	if enum != nil {
+		field.AddEnum(enum)
+	}
 
-	TypeName   string   `pg:"typname"`
-	EnumValues []string `pg:"enum_values,array"`
+	return field
 }
 
-func (c column) Column(useSQLNulls bool, goPGVer int) model.Column {
-	return model.NewColumn(c.Name, c.Type, c.IsNullable, useSQLNulls, c.IsArray, c.Dimensions, c.IsPK, c.IsFK, c.MaxLen, c.EnumType, c.Values, goPGVer)
+type check struct {
+	Schema     string `pg:"schema_name"`
+	Table      string `pg:"table_name"`
+	Column     string `pg:"column_name"`
+	Name       string `pg:"constraint_name"`
+	Definition string `pg:"definition"`
+}
+
+type enum struct {
+	Schema string   `pg:"schema_name"`
+	Name   string   `pg:"type_name"`
+	Values []string `pg:"enum_values,array"`
+}
+
+func (e enum) Enum() model.Enum {
+	return model.NewEnum(e.Schema, e.Name, e.Values)
+}
+
+type extension struct {
+	Name   string `pg:"extension_name"`
+	Schema string `pg:"schema_name"`
+}
+
+// extensionType gets pg type for column, types of extensions not installed in type schema are not mapped
+func extensionType(pgType, schema string, extensions map[string]string) string {
+	if ext := model.Extension(pgType); ext != "" && extensions[ext] != schema {
+		return util.Join(schema, pgType)
+	}
+	return pgType
+}
+
+// enumType gets pg type for column, enums are stored as strings
+func enumType(pgType string, enum *model.Enum) string {
+	if enum != nil {
+		return model.TypePGVarchar
+	}
+	return pgType
+}
+
+func (c column) Column(enum *model.Enum, useSQLNulls, useDecimal bool, goPGVer int) model.Column {
+	column := model.NewColumn(c.Name, enumType(c.Type, enum), c.IsNullable, useSQLNulls, c.IsArray, c.Dimensions, c.IsPK, c.IsFK, c.MaxLen, "", []string{}, goPGVer)
+	if useDecimal {
+		column.UseDecimal()
+	}
+	if enum != nil {
+		column.AddEnum(enum)
+	}
+	column.Description = c.Comment
+	column.Precision = c.Precision
+	column.Scale = c.Scale
+	column.Domain = c.Domain
+	column.Identity = c.Identity
+	column.Generated = c.Generated
+	column.Sequence = c.Sequence
+	column.IsInherited = c.Inherited
+	column.AddDefault(c.Default)
+
+	return column
+}
+
+// argument modes of pg_proc, resultMode is used for results declared by return type
+const (
+	modeIn       = "i"
+	modeOut      = "o"
+	modeInOut    = "b"
+	modeVariadic = "v"
+	modeTable    = "t"
+	modeResult   = "r"
+)
+
+type functionArg struct {
+	ID         int64  `pg:"function_id"`
+	Schema     string `pg:"schema_name"`
+	FuncName   string `pg:"function_name"`
+	Kind       string `pg:"function_kind"`
+	Comment    string `pg:"function_comment"`
+	ReturnType string `pg:"return_type"`
+	ReturnsSet bool   `pg:"returns_set"`
+	Defaults   int    `pg:"defaults"`
+
+	Position   int    `pg:"arg_position"`
+	Name       string `pg:"arg_name"`
+	Mode       string `pg:"arg_mode"`
+	Type       string `pg:"arg_type"`
+	TypeSchema string `pg:"arg_type_schema"`
+	IsArray    bool   `pg:"arg_array"`
+}
+
+func (a functionArg) Function() model.Function {
+	function := model.NewFunction(a.Schema, a.FuncName, a.ReturnType, a.ReturnsSet, a.Kind == "p")
+	function.Description = a.Comment
+
+	return function
+}
+
+// IsInput checks if argument is passed to function
+func (a functionArg) IsInput() bool {
+	return a.Mode == modeIn || a.Mode == modeInOut || a.Mode == modeVariadic
+}
+
+// IsOutput checks if argument is returned by function
+func (a functionArg) IsOutput() bool {
+	return a.Mode == modeOut || a.Mode == modeInOut || a.Mode == modeTable || a.Mode == modeResult
+}
+
+// Column creates column for argument or result, unnamed arguments are named like postgres does
+// results are nullable, arguments are not
+func (a functionArg) Column(output bool, enum *model.Enum, useSQLNulls, useDecimal bool, goPGVer int) model.Column {
+	name := a.Name
+	if name == "" {
+		name = fmt.Sprintf("column%d", a.Position)
+		if !output {
+			name = fmt.Sprintf("arg%d", a.Position)
+		}
+	}
+
+	dims := 0
+	if a.IsArray {
+		dims = 1
+	}
+
+	column := model.NewColumn(name, enumType(a.Type, enum), output, useSQLNulls, a.IsArray, dims, false, false, 0, "", []string{}, goPGVer)
+	if useDecimal {
+		column.UseDecimal()
+	}
+	if enum != nil {
+		column.AddEnum(enum)
+	}
+
+	return column
+}
+
+type sequence struct {
+	Schema  string `pg:"schema_name"`
+	Name    string `pg:"sequence_name"`
+	Type    string `pg:"sequence_type"`
+	Comment string `pg:"sequence_comment"`
+
+	OwnerSchema string `pg:"table_schema"`
+	OwnerTable  string `pg:"table_name"`
+	OwnerColumn string `pg:"column_name"`
+}
+
+func (s sequence) Sequence() model.Sequence {
+	seq := model.NewSequence(s.Schema, s.Name, s.Type)
+	seq.Description = s.Comment
+	if s.OwnerColumn != "" {
+		seq.AddOwner(s.OwnerSchema, s.OwnerTable, s.OwnerColumn)
+	}
+
+	return seq
+}
+
+// Selected checks if sequence or table owning it is listed by name or matches any pattern
+// and neither of them is excluded
+func (s sequence) Selected(include, exclude util.Matcher) bool {
+	owned := s.OwnerColumn != ""
+	if exclude.Match(s.Schema, s.Name) || owned && exclude.Match(s.OwnerSchema, s.OwnerTable) {
+		return false
+	}
+
+	return include.Match(s.Schema, s.Name) || owned && include.Match(s.OwnerSchema, s.OwnerTable)
 }
 
 // Store is database helper
 type store struct {
-	db     orm.DB
-	enumDT []enumDataType
+	// ctx is used by all queries, so they are cancelled with it
+	ctx context.Context
+	db  orm.DB
+
+	// sources are sources of queries, pg_catalog is used if query is not listed
+	sources map[string]string
+
+	// pool and snapshot are set if db is a transaction with exported snapshot, see parallel
+	pool     *pg.DB
+	snapshot string
 }
 
 // NewStore creates Store
-func newStore(db orm.DB) *store {
-	return &store{db: db}
+func newStore(ctx context.Context, db orm.DB, sources map[string]string) *store {
+	return &store{ctx: ctx, db: db, sources: sources}
+}
+
+// source gets source of query, see SourceCatalog and SourceInformationSchema
+func (s store) source(query string) string {
+	if source, ok := s.sources[query]; ok {
+		return source
+	}
+
+	return SourceCatalog
 }
 
-func (s *store) Tables(selected []string) ([]table, error) {
-	var schemas []string
-	var tables []interface{}
+// parallel runs independent reads concurrently on connections of pool,
+// every read is run in its own transaction importing snapshot, so all of them see same state of database
+// reads are run one by one if snapshot is not exported
+func (s *store) parallel(reads ...func(s *store) error) error {
+	if s.pool == nil || s.snapshot == "" {
+		for _, read := range reads {
+			if err := read(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	errs := make([]error, len(reads))
+
+	var wg sync.WaitGroup
+	for i, read := range reads {
+		wg.Add(1)
+		go func(i int, read func(s *store) error) {
+			defer wg.Done()
+
+			tx, err := begin(s.ctx, s.pool, s.snapshot)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer func() { _ = tx.Rollback() }()
 
-	for _, s := range selected {
-		schema, table := util.Split(s)
-		if table == "*" {
-			schemas = append(schemas, schema)
-		} else {
-			tables = append(tables, []string{schema, table})
+			errs[i] = read(newStore(s.ctx, tx, s.sources))
+		}(i, read)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
 		}
 	}
 
-	var where []string
-	if len(schemas) > 0 {
-		where = append(where, format("(table_schema) in (?)", pg.In(schemas)))
+	return nil
+}
+
+// Tables gets tables listed by name or matching patterns of include and not matching exclude
+// partitions are read only if listed by name, foreign tables are read only if foreign set
+func (s *store) Tables(include, exclude util.Matcher, foreign bool) ([]table, error) {
+	kinds := []string{kindTable, kindPartitioned, kindView, kindMatView}
+	if foreign {
+		kinds = append(kinds, kindForeign)
 	}
-	if len(tables) > 0 {
-		where = append(where, format("(table_schema, table_name) in (?)", pg.InMulti(tables...)))
+
+	// schemas are filtered in database, so large databases are not read entirely
+	filter := format("c.relkind in (?)", pg.In(kinds))
+	if schemas, ok := include.Schemas(); ok {
+		if len(schemas) == 0 {
+			return nil, nil
+		}
+		filter += format(" and n.nspname in (?)", pg.In(schemas))
 	}
 
 	query := `
         select 
-            table_schema,
-            table_name
-        from information_schema.tables
+            n.nspname as table_schema,
+            c.relname as table_name,
+            c.relkind as table_kind,
+            obj_description(c.oid, 'pg_class') as table_comment,
+            case when c.relkind = 'p' then pg_get_partkeydef(c.oid) end as partition_key,
+            ` + isPartition + ` as is_partition
+        from pg_class c
+        join pg_namespace n on n.oid = c.relnamespace
         where 
-            table_type = 'BASE TABLE' and 
-            (
-                ` + strings.Join(where, "or \n") + `
-            )`
+            ` + filter + `
+        order by 1, 2`
 
-	var result []table
-	if _, err := s.db.Query(&result, query); err != nil {
+	var tables []table
+	if _, err := s.db.QueryContext(s.ctx, &tables, query); err != nil {
 		return nil, fmt.Errorf("getting tables info error: %w", err)
 	}
 
+	var result []table
+	for _, t := range tables {
+		if t.Selected(include) && !exclude.Match(t.Schema, t.Name) {
+			result = append(result, t)
+		}
+	}
+
 	return result, nil
 }
 
+// Inherits gets parents of selected tables, partitions are not considered children
+func (s *store) Inherits(tables []table) ([]inheritance, error) {
+	ts := make([]interface{}, len(tables))
+	for i, t := range tables {
+		ts[i] = []string{t.Schema, t.Name}
+	}
+
+	query := `
+		select cn.nspname as schema_name,
+		       c.relname  as table_name,
+		       pn.nspname as parent_schema,
+		       p.relname  as parent_table,
+		       p.relkind  as parent_kind
+		from pg_inherits i
+		join pg_class c on c.oid = i.inhrelid
+		join pg_namespace cn on cn.oid = c.relnamespace
+		join pg_class p on p.oid = i.inhparent
+		join pg_namespace pn on pn.oid = p.relnamespace
+		where not ` + isPartition + `
+		  and (cn.nspname, c.relname) in (?)
+		order by cn.nspname, c.relname, i.inhseqno
+	`
+
+	var inherits []inheritance
+	if _, err := s.db.QueryContext(s.ctx, &inherits, query, pg.InMulti(ts...)); err != nil {
+		return nil, fmt.Errorf("getting inheritance info error: %w", err)
+	}
+
+	return inherits, nil
+}
+
 // Relations gets relations of a selected table
 func (s *store) Relations(tables []table) ([]relation, error) {
 	ts := make([]interface{}, len(tables))
@@ -157,7 +559,24 @@ func (s *store) Relations(tables []table) ([]relation, error) {
 		       array_agg(sc.attname) as columns,
 		       ts.nspname            as target_schema,
 		       t.relname             as target_table,
-		       array_agg(tc.attname) as target_columns
+		       array_agg(tc.attname) as target_columns,
+		       bool_or(exists(
+		           select 1
+		           from pg_index i
+		           where i.indrelid = co.conrelid
+		             and i.indisunique
+		             and i.indpred is null
+		             and string_to_array(i.indkey::text, ' ')::int2[] @> co.conkey
+		             and string_to_array(i.indkey::text, ' ')::int2[] <@ co.conkey
+		       ))                    as is_unique,
+		       bool_or(exists(
+		           select 1
+		           from pg_constraint pk
+		           where pk.conrelid = co.confrelid
+		             and pk.contype = 'p'
+		             and pk.conkey @> co.confkey
+		             and pk.conkey <@ co.confkey
+		       ))                    as is_primary
 		from pg_constraint co
 		left join tables s on co.conrelid = s.oid
 		left join schemas ss on s.relnamespace = ss.oid
@@ -166,76 +585,545 @@ func (s *store) Relations(tables []table) ([]relation, error) {
 		left join schemas ts on t.relnamespace = ts.oid
 		left join columns tc on t.oid = tc.attrelid and tc.attnum = any (co.confkey)
 		where co.contype = 'f'
-		  and co.conrelid in (select oid from pg_class c where c.relkind = 'r')
+		  and co.conrelid in (select oid from pg_class c where c.relkind in ('r', 'p'))
+		  -- constraints cloned to partitions are skipped, parent constraint is used instead
+		  and coalesce((to_jsonb(co) ->> 'conparentid')::oid, 0) = 0
 		  and array_position(co.conkey, sc.attnum) = array_position(co.confkey, tc.attnum)
 		  and (ss.nspname, s.relname) in (?)
 		group by constraint_name, schema_name, table_name, target_schema, target_table
 	`
 
 	var relations []relation
-	if _, err := s.db.Query(&relations, query, pg.InMulti(ts...)); err != nil {
+	if _, err := s.db.QueryContext(s.ctx, &relations, query, pg.InMulti(ts...)); err != nil {
 		return nil, fmt.Errorf("getting relations info error: %w", err)
 	}
 
 	return relations, nil
 }
 
-// EnumType   string   `pg:"enumtype"`
-// 	Values     []string `pg:"enum,array"`
-func (s store) findEnumDataType(t string) (string, []string) {
-	var enumType string = ""
-	var enums []string
-	if len(s.enumDT) > 0 {
-		for _, enm := range s.enumDT {
-			if enm.TypeName == t {
-				enumType = enm.TypeName
-				enums = enm.EnumValues
-				break
-			}
-		}
+// Indexes gets indexes and unique constraints of selected tables
+func (s *store) Indexes(tables []table) ([]tableIndex, error) {
+	ts := make([]interface{}, len(tables))
+	for i, t := range tables {
+		ts[i] = []string{t.Schema, t.Name}
+	}
+
+	query := `
+		select ns.nspname                          as schema_name,
+		       tb.relname                          as table_name,
+		       ix.relname                          as index_name,
+		       array(
+		           select coalesce(a.attname::text, pg_get_indexdef(x.indexrelid, k.n, true))
+		           from generate_series(1, x.indnkeyatts) k(n)
+		           left join pg_attribute a on a.attrelid = x.indrelid
+		                                    and a.attnum = x.indkey[k.n - 1]
+		                                    and a.attnum > 0
+		           order by k.n
+		       )                                   as columns,
+		       x.indisunique                       as is_unique,
+		       x.indisprimary                      as is_primary,
+		       co.oid is not null                  as is_constraint,
+		       x.indexprs is not null              as has_expressions,
+		       pg_get_expr(x.indpred, x.indrelid)  as predicate
+		from pg_index x
+		join pg_class tb on tb.oid = x.indrelid
+		join pg_class ix on ix.oid = x.indexrelid
+		join pg_namespace ns on ns.oid = tb.relnamespace
+		left join pg_constraint co on co.conindid = x.indexrelid
+		                           and co.conrelid = x.indrelid
+		                           and co.contype in ('p', 'u')
+		where (ns.nspname, tb.relname) in (?)
+		order by ns.nspname, tb.relname, x.indisprimary desc, ix.relname
+	`
+
+	var indexes []tableIndex
+	if _, err := s.db.QueryContext(s.ctx, &indexes, query, pg.InMulti(ts...)); err != nil {
+		return nil, fmt.Errorf("getting indexes info error: %w", err)
 	}
 
-	return enumType, enums
+	return indexes, nil
 }
 
-func (s *store) EnumDataType() {
-	query := `select pg_type.typname, array_agg(e.enumlabel) as enum_values
-	from pg_enum e
-	join pg_type on pg_type.oid = e.enumtypid
-	group by 1`
+// Composites gets fields of all user-defined composite types
+func (s *store) Composites() ([]compositeField, error) {
+	query := `
+		select ns.nspname                                   as schema_name,
+		       t.typname                                    as type_name,
+		       a.attname                                    as field_name,
+		       ltrim(coalesce(bt.typname, ft.typname), '_') as field_type,
+		       fns.nspname                                  as field_type_schema,
+		       ft.typcategory = 'A'                         as array,
+		       case
+		       when ft.typcategory = 'A' then greatest(a.attndims, 1)
+		       else 0
+		       end                                          as dims,
+		       case
+		       when a.atttypmod > 4 and ft.typname in ('varchar', 'bpchar', '_varchar', '_bpchar')
+		       then a.atttypmod - 4
+		       end                                          as len
+		from pg_type t
+		join pg_namespace ns on ns.oid = t.typnamespace
+		join pg_class c on c.oid = t.typrelid
+		join pg_attribute a on a.attrelid = c.oid
+		join pg_type ft on ft.oid = a.atttypid
+		join pg_namespace fns on fns.oid = ft.typnamespace
+		-- fields of domain types are resolved to base type
+		left join pg_type bt on bt.oid = ft.typbasetype and ft.typtype = 'd'
+		where t.typtype = 'c'
+		  and c.relkind = 'c'
+		  and a.attnum > 0
+		  and not a.attisdropped
+		order by ns.nspname, t.typname, a.attnum
+	`
 
-	var enumDataTyp []enumDataType
-	if _, err := s.db.Query(&enumDataTyp, query); err != nil {
-		fmt.Errorf("getting enum data type info error: %w", err)
+	var fields []compositeField
+	if _, err := s.db.QueryContext(s.ctx, &fields, query); err != nil {
+		return nil, fmt.Errorf("getting composites info error: %w", err)
 	}
-	s.enumDT = enumDataTyp
+
+	return fields, nil
 }
 
-func (s store) Columns(tables []table) ([]column, error) {
+// Checks gets check constraints of selected tables and domains of their columns
+// column name is set for domain constraints only
+func (s *store) Checks(tables []table) ([]check, error) {
 	ts := make([]interface{}, len(tables))
 	for i, t := range tables {
 		ts[i] = []string{t.Schema, t.Name}
 	}
 
-	if len(s.enumDT) == 0 {
-		s.EnumDataType()
+	query := `
+		with recursive
+		    domains as (
+		        select t.oid as domain_oid,
+		               t.oid as type_oid
+		        from pg_type t
+		        where t.typtype = 'd'
+		        union all
+		        select d.domain_oid,
+		               b.typbasetype
+		        from domains d
+		        join pg_type b on b.oid = d.type_oid
+		        where b.typtype = 'd'
+		    ),
+		    checks as (
+		        select ns.nspname                     as schema_name,
+		               tb.relname                     as table_name,
+		               null::text                     as column_name,
+		               co.conname                     as constraint_name,
+		               pg_get_constraintdef(co.oid)   as definition
+		        from pg_constraint co
+		        join pg_class tb on tb.oid = co.conrelid
+		        join pg_namespace ns on ns.oid = tb.relnamespace
+		        where co.contype = 'c'
+		        union all
+		        select ns.nspname                     as schema_name,
+		               tb.relname                     as table_name,
+		               col.attname::text              as column_name,
+		               co.conname                     as constraint_name,
+		               pg_get_constraintdef(co.oid)   as definition
+		        from pg_class tb
+		        join pg_namespace ns on ns.oid = tb.relnamespace
+		        join pg_attribute col on col.attrelid = tb.oid
+		        join pg_type ct on ct.oid = col.atttypid
+		        join domains d on d.domain_oid = ct.oid
+		        join pg_constraint co on co.contypid = d.type_oid
+		        where co.contype = 'c'
+		          and col.attnum > 0
+		          and not col.attisdropped
+		    )
+		select *
+		from checks
+		where (schema_name, table_name) in (?)
+		order by schema_name, table_name, column_name nulls first, constraint_name
+	`
+
+	var checks []check
+	if _, err := s.db.QueryContext(s.ctx, &checks, query, pg.InMulti(ts...)); err != nil {
+		return nil, fmt.Errorf("getting checks info error: %w", err)
+	}
+
+	return checks, nil
+}
+
+// Enums gets all enum types with values in order of declaration
+func (s *store) Enums() ([]enum, error) {
+	query := `
+		select ns.nspname                                          as schema_name,
+		       t.typname                                           as type_name,
+		       array_agg(e.enumlabel::text order by e.enumsortorder) as enum_values
+		from pg_type t
+		join pg_namespace ns on ns.oid = t.typnamespace
+		join pg_enum e on e.enumtypid = t.oid
+		group by ns.nspname, t.typname
+		order by 1, 2
+	`
+
+	var enums []enum
+	if _, err := s.db.QueryContext(s.ctx, &enums, query); err != nil {
+		return nil, fmt.Errorf("getting enums info error: %w", err)
+	}
+
+	return enums, nil
+}
+
+// Extensions gets all installed extensions with their schemas
+func (s *store) Extensions() ([]extension, error) {
+	query := `
+		select e.extname   as extension_name,
+		       ns.nspname  as schema_name
+		from pg_extension e
+		join pg_namespace ns on ns.oid = e.extnamespace
+		order by 1
+	`
+
+	var extensions []extension
+	if _, err := s.db.QueryContext(s.ctx, &extensions, query); err != nil {
+		return nil, fmt.Errorf("getting extensions info error: %w", err)
 	}
 
+	return extensions, nil
+}
+
+// Functions gets arguments and results of functions and procedures listed by name or matching patterns
+// functions of extensions and system schemas are skipped, function without arguments and results has one empty row
+func (s *store) Functions(include, exclude util.Matcher) ([]functionArg, error) {
+	kind := `coalesce(to_jsonb(p) ->> 'prokind', case when (to_jsonb(p) ->> 'proisagg')::boolean then 'a' else 'f' end)`
+
 	query := `
 		with
-		    enums as (
-		        select distinct true                   as is_enum,
-		                        sch.nspname            as table_schema,
-		                        tb.relname             as table_name,
-								col.attname            as column_name,
-								pg_type.typname,
-                                array_agg(e.enumlabel) as enum_values
+		    funcs as (
+		        select p.oid,
+		               n.nspname                         as schema_name,
+		               p.proname                         as function_name,
+		               ` + kind + `                      as function_kind,
+		               obj_description(p.oid, 'pg_proc') as function_comment,
+		               p.prorettype,
+		               p.proretset                       as returns_set,
+		               p.pronargdefaults                 as defaults,
+		               p.pronargs,
+		               p.proargtypes,
+		               p.proallargtypes,
+		               p.proargmodes,
+		               p.proargnames
+		        from pg_proc p
+		        join pg_namespace n on n.oid = p.pronamespace
+		        where n.nspname <> 'information_schema'
+		          and n.nspname not like 'pg\_%'
+		          and ` + kind + ` in ('f', 'p')
+		          and not exists(
+		              select 1
+		              from pg_depend d
+		              where d.classid = 'pg_proc'::regclass
+		                and d.objid = p.oid
+		                and d.deptype = 'e'
+		          )
+		    ),
+		    args as (
+		        select f.oid, a.position::int, a.name, a.mode::text, a.type
+		        from funcs f,
+		             unnest(
+		                 coalesce(f.proallargtypes, f.proargtypes::oid[]),
+		                 coalesce(f.proargmodes, array_fill('i'::"char", array [f.pronargs::int])),
+		                 f.proargnames
+		             ) with ordinality a(type, mode, name, position)
+		        where a.type is not null
+		        union all
+		        -- scalar result is named as function
+		        select f.oid, 0, f.function_name::text, 'r', t.oid
+		        from funcs f
+		        join pg_type t on t.oid = f.prorettype
+		        where f.proallargtypes is null
+		          and t.typtype not in ('p', 'c')
+		        union all
+		        -- attributes of returned composite or table type
+		        select f.oid, at.attnum::int, at.attname::text, 'r', at.atttypid
+		        from funcs f
+		        join pg_type t on t.oid = f.prorettype
+		        join pg_attribute at on at.attrelid = t.typrelid and at.attnum > 0 and not at.attisdropped
+		        where f.proallargtypes is null
+		          and t.typtype = 'c'
+		    )
+		select f.oid::bigint                              as function_id,
+		       f.schema_name,
+		       f.function_name,
+		       f.function_kind,
+		       f.function_comment,
+		       rt.typname                                 as return_type,
+		       f.returns_set,
+		       f.defaults,
+		       coalesce(a.position, 0)                    as arg_position,
+		       coalesce(a.name, '')                       as arg_name,
+		       coalesce(a.mode, '')                       as arg_mode,
+		       ltrim(coalesce(bt.typname, t.typname), '_') as arg_type,
+		       coalesce(bn.nspname, tn.nspname)           as arg_type_schema,
+		       coalesce(coalesce(bt.typcategory, t.typcategory) = 'A', false) as arg_array
+		from funcs f
+		join pg_type rt on rt.oid = f.prorettype
+		left join args a on a.oid = f.oid
+		left join pg_type t on t.oid = a.type
+		left join pg_namespace tn on tn.oid = t.typnamespace
+		-- domains are resolved to base types
+		left join pg_type bt on bt.oid = t.typbasetype and t.typtype = 'd'
+		left join pg_namespace bn on bn.oid = bt.typnamespace
+		order by 2, 3, 1, a.mode = 'r', a.position
+	`
+
+	var args []functionArg
+	if _, err := s.db.QueryContext(s.ctx, &args, query); err != nil {
+		return nil, fmt.Errorf("getting functions info error: %w", err)
+	}
+
+	var result []functionArg
+	for _, a := range args {
+		if include.Match(a.Schema, a.FuncName) && !exclude.Match(a.Schema, a.FuncName) {
+			result = append(result, a)
+		}
+	}
+
+	return result, nil
+}
+
+// Sequences gets sequences listed by name or matching patterns, or owned by such tables
+// sequences of system schemas are skipped
+func (s *store) Sequences(include, exclude util.Matcher) ([]sequence, error) {
+	query := `
+		with owned as (` + ownedSequences + `
+		)
+		select n.nspname                          as schema_name,
+		       c.relname                          as sequence_name,
+		       t.typname                          as sequence_type,
+		       obj_description(c.oid, 'pg_class') as sequence_comment,
+		       o.table_schema,
+		       o.table_name,
+		       o.column_name
+		from pg_sequence s
+		join pg_class c on c.oid = s.seqrelid
+		join pg_namespace n on n.oid = c.relnamespace
+		join pg_type t on t.oid = s.seqtypid
+		left join owned o on o.sequence_oid = c.oid
+		where n.nspname <> 'information_schema'
+		  and n.nspname not like 'pg\_%'
+		order by 1, 2
+	`
+
+	if s.source(QuerySequences) == SourceInformationSchema {
+		query = `
+		with owned as (` + ownedSequences + `
+		)
+		select n.nspname                        as schema_name,
+		       c.relname                        as sequence_name,
+		       -- data type is always bigint on servers before 10
+		       case s.data_type
+		       when 'smallint' then 'int2'
+		       when 'integer' then 'int4'
+		       else 'int8'
+		       end                              as sequence_type,
+		       obj_description(c.oid, 'pg_class') as sequence_comment,
+		       o.table_schema,
+		       o.table_name,
+		       o.column_name
+		from information_schema.sequences s
+		join pg_namespace n on n.nspname = s.sequence_schema
+		join pg_class c on c.relnamespace = n.oid and c.relname = s.sequence_name and c.relkind = 'S'
+		left join owned o on o.sequence_oid = c.oid
+		where n.nspname <> 'information_schema'
+		  and n.nspname not like 'pg\_%'
+		order by 1, 2
+	`
+	}
+
+	var sequences []sequence
+	if _, err := s.db.QueryContext(s.ctx, &sequences, query); err != nil {
+		return nil, fmt.Errorf("getting sequences info error: %w", err)
+	}
+
+	var result []sequence
+	for _, seq := range sequences {
+		if seq.Selected(include, exclude) {
+			result = append(result, seq)
+		}
+	}
+
+	return result, nil
+}
+
+// columnsCatalog reads columns from pg_catalog, output is the same as of columnsInformationSchema
+// attributes are read only for selected tables, so it is fast on large schemas
+const columnsCatalog = `
+		with recursive
+		    domains as (
+		        select t.oid         as domain_oid,
+		               t.typname     as domain_name,
+		               t.typbasetype as base_oid,
+		               t.typnotnull  as not_null
+		        from pg_type t
+		        where t.typtype = 'd'
+		        union all
+		        -- domains can be based on other domains
+		        select d.domain_oid,
+		               d.domain_name,
+		               b.typbasetype,
+		               d.not_null or b.typnotnull
+		        from domains d
+		        join pg_type b on b.oid = d.base_oid
+		        where b.typtype = 'd'
+		    ),
+		    selected as (
+		        select c.oid,
+		               n.nspname as table_schema,
+		               c.relname as table_name,
+		               -- identity, generated and inherited columns are read for tables only
+		               c.relkind in ('r', 'p', 'f') as is_table
+		        from pg_class c
+		        join pg_namespace n on n.oid = c.relnamespace
+		        where c.relkind in ('r', 'p', 'v', 'f', 'm')
+		          and (n.nspname, c.relname) in (?)
+		    ),
+		    keys as (
+		        select k.conrelid,
+		               k.attnum,
+		               bool_or(k.contype = 'p') as pk,
+		               bool_or(k.contype = 'f') as fk
+		        from (
+		            select con.conrelid, con.contype, unnest(con.conkey) as attnum
+		            from pg_constraint con
+		            join selected s on s.oid = con.conrelid
+		            where con.contype in ('p', 'f')
+		        ) k
+		        group by 1, 2
+		    )
+		select s.table_schema = 'public'                      as is_public,
+		       s.table_schema                                 as schema_name,
+		       s.table_name                                   as table_name,
+		       a.attname                                      as column_name,
+		       a.attnum                                       as ordinal,
+		       coalesce(k.pk, false)                          as pk,
+		       coalesce(k.fk, false)                          as fk,
+		       not a.attnotnull and not coalesce(d.not_null, false) as nullable,
+		       x.is_array                                     as array,
+		       case
+		       -- attndims is not set for domains over arrays and for columns of views
+		       when x.is_array
+		       then greatest(a.attndims, 1)
+		       else 0
+		       end                                            as dims,
+		       ltrim(coalesce(d.base_type, x.udt_name), '_')  as type,
+		       case when not x.generated then pg_get_expr(ad.adbin, ad.adrelid) end as def,
+		       case
+		       when x.typmod <> -1 and x.type_oid in ('bpchar'::regtype, 'varchar'::regtype)
+		       then x.typmod - 4
+		       when x.typmod <> -1 and x.type_oid in ('bit'::regtype, 'varbit'::regtype)
+		       then x.typmod
+		       end                                            as len,
+		       -- precision and scale are packed to typmod of numeric
+		       case
+		       when x.typmod <> -1 and x.type_oid = 'numeric'::regtype
+		       then ((x.typmod - 4) >> 16) & 65535
+		       end                                            as precision,
+		       case
+		       when x.typmod <> -1 and x.type_oid = 'numeric'::regtype
+		       then (x.typmod - 4) & 65535
+		       end                                            as scale,
+		       col_description(s.oid, a.attnum)               as comment,
+		       d.domain_name                                  as domain,
+		       coalesce(d.base_schema, x.udt_schema)          as type_schema,
+		       case when s.is_table then x.identity end       as identity,
+		       s.is_table and x.generated                     as generated,
+		       s.is_table and a.attinhcount > 0               as inherited,
+		       o.sequence                                     as sequence
+		from selected s
+		join pg_attribute a on a.attrelid = s.oid and a.attnum > 0 and not a.attisdropped
+		join pg_type t on t.oid = a.atttypid
+		join pg_namespace tn on tn.oid = t.typnamespace
+		left join pg_type bt on bt.oid = t.typbasetype and t.typtype = 'd'
+		left join pg_namespace bn on bn.oid = bt.typnamespace
+		left join pg_attrdef ad on ad.adrelid = a.attrelid and ad.adnum = a.attnum
+		left join keys k on k.conrelid = s.oid and k.attnum = a.attnum
+		-- type of column or base type of domain, attidentity and attgenerated are read by name, so older servers are supported
+		cross join lateral (
+		    select coalesce(bt.oid, t.oid)                                        as type_oid,
+		           case when t.typtype = 'd' then t.typtypmod else a.atttypmod end as typmod,
+		           coalesce(bt.typname, t.typname)                                as udt_name,
+		           coalesce(bn.nspname, tn.nspname)                               as udt_schema,
+		           coalesce(bt.typelem, t.typelem) <> 0 and coalesce(bt.typlen, t.typlen) = -1 as is_array,
+		           nullif(to_jsonb(a) ->> 'attidentity', '')                      as identity,
+		           coalesce(to_jsonb(a) ->> 'attgenerated', '') = 's'             as generated
+		) x
+		-- domains of columns and elements of arrays are resolved to base types
+		left join lateral (
+		    select d.domain_name,
+		           dt.typname  as base_type,
+		           dn.nspname  as base_schema,
+		           d.not_null
+		    from domains d
+		    join pg_type dt on dt.oid = d.base_oid and dt.typtype <> 'd'
+		    join pg_namespace dn on dn.oid = dt.typnamespace
+		    where d.domain_oid = case
+		                         when t.typtype <> 'd' and t.typcategory = 'A'
+		                         then t.typelem
+		                         else t.oid
+		                         end
+		) d on true
+		-- column could own several sequences, pg_get_serial_sequence gets any of them too
+		left join lateral (
+		    select format('%I.%I', sn.nspname, sq.relname) as sequence
+		    from pg_depend dep
+		    join pg_class sq on sq.oid = dep.objid and sq.relkind = 'S'
+		    join pg_namespace sn on sn.oid = sq.relnamespace
+		    where dep.classid = 'pg_class'::regclass
+		      and dep.refclassid = 'pg_class'::regclass
+		      and dep.refobjid = s.oid
+		      and dep.refobjsubid = a.attnum
+		      and dep.deptype in ('a', 'i')
+		    order by 1
+		    limit 1
+		) o on true
+		order by 1 desc, 2, 3, 5 asc, 6 desc nulls last
+	`
+
+// columnsInformationSchema reads columns from information_schema, materialized views are read from pg_catalog
+const columnsInformationSchema = `
+		with recursive
+		    domains as (
+		        select t.oid         as domain_oid,
+		               t.typname     as domain_name,
+		               t.typbasetype as base_oid,
+		               t.typnotnull  as not_null
+		        from pg_type t
+		        where t.typtype = 'd'
+		        union all
+		        -- domains can be based on other domains
+		        select d.domain_oid,
+		               d.domain_name,
+		               b.typbasetype,
+		               d.not_null or b.typnotnull
+		        from domains d
+		        join pg_type b on b.oid = d.base_oid
+		        where b.typtype = 'd'
+		    ),
+		    domain_columns as (
+		        select sch.nspname   as table_schema,
+		               tb.relname    as table_name,
+		               col.attname   as column_name,
+		               d.domain_name,
+		               bt.typname    as base_type,
+		               bns.nspname   as base_schema,
+		               d.not_null
 		        from pg_class tb
-		        left join pg_namespace sch on sch.oid = tb.relnamespace
-		        left join pg_attribute col on col.attrelid = tb.oid
-				inner join pg_enum e on e.enumtypid = col.atttypid
-				inner join pg_type ON pg_type.oid = e.enumtypid
-				group by 1, 2, 3, 4, 5
+		        join pg_namespace sch on sch.oid = tb.relnamespace
+		        join pg_attribute col on col.attrelid = tb.oid
+		        join pg_type ct on ct.oid = col.atttypid
+		        join domains d on d.domain_oid = case
+		                                         when ct.typtype <> 'd' and ct.typcategory = 'A'
+		                                         then ct.typelem
+		                                         else ct.oid
+		                                         end
+		        join pg_type bt on bt.oid = d.base_oid
+		        join pg_namespace bns on bns.oid = bt.typnamespace
+		        where bt.typtype <> 'd'
+		          and col.attnum > 0
+		          and not col.attisdropped
 		    ),
 		    arrays as (
 		        select sch.nspname  as table_schema,
@@ -247,6 +1135,88 @@ func (s store) Columns(tables []table) ([]column, error) {
 		        left join pg_attribute col on col.attrelid = tb.oid
 		        where col.attndims > 0
 		    ),
+		    columns as (
+		        select c.table_schema,
+		               c.table_name,
+		               c.column_name,
+		               c.ordinal_position,
+		               c.is_nullable = 'YES' as is_nullable,
+		               c.data_type = 'ARRAY' as is_array,
+		               c.udt_schema,
+		               c.udt_name,
+		               c.column_default,
+		               c.character_maximum_length,
+		               case when c.data_type = 'numeric' then c.numeric_precision end as numeric_precision,
+		               case when c.data_type = 'numeric' then c.numeric_scale end     as numeric_scale,
+		               col_description(format('%I.%I', c.table_schema, c.table_name)::regclass, c.ordinal_position::int) as column_comment
+		        from information_schema.columns c
+		        join information_schema.tables t using (table_name, table_schema)
+		        where t.table_type in ('BASE TABLE', 'VIEW', 'FOREIGN', 'FOREIGN TABLE')
+		        union all
+		        -- materialized views are not listed in information_schema
+		        select sch.nspname                  as table_schema,
+		               tb.relname                   as table_name,
+		               col.attname                  as column_name,
+		               col.attnum                   as ordinal_position,
+		               not col.attnotnull           as is_nullable,
+		               typ.typcategory = 'A'        as is_array,
+		               typ_sch.nspname              as udt_schema,
+		               typ.typname                  as udt_name,
+		               null                         as column_default,
+		               -- length, precision and scale are read by rules of information_schema
+		               case
+		               when tt.typmod <> -1 and tt.oid in ('bpchar'::regtype, 'varchar'::regtype)
+		               then tt.typmod - 4
+		               when tt.typmod <> -1 and tt.oid in ('bit'::regtype, 'varbit'::regtype)
+		               then tt.typmod
+		               end                          as character_maximum_length,
+		               -- precision and scale are packed to typmod of numeric
+		               case
+		               when tt.typmod <> -1 and tt.oid = 'numeric'::regtype
+		               then ((tt.typmod - 4) >> 16) & 65535
+		               end                          as numeric_precision,
+		               case
+		               when tt.typmod <> -1 and tt.oid = 'numeric'::regtype
+		               then (tt.typmod - 4) & 65535
+		               end                          as numeric_scale,
+		               col_description(tb.oid, col.attnum) as column_comment
+		        from pg_class tb
+		        join pg_namespace sch on sch.oid = tb.relnamespace
+		        join pg_attribute col on col.attrelid = tb.oid
+		        join pg_type typ on typ.oid = col.atttypid
+		        join pg_namespace typ_sch on typ_sch.oid = typ.typnamespace
+		        -- type of column or base type of domain with its typmod
+		        cross join lateral (
+		            select case when typ.typtype = 'd' then typ.typbasetype else typ.oid end       as oid,
+		                   case when typ.typtype = 'd' then typ.typtypmod else col.atttypmod end as typmod
+		        ) tt
+		        where tb.relkind = 'm'
+		          and col.attnum > 0
+		          and not col.attisdropped
+		    ),
+		    managed as (
+		        -- attidentity and attgenerated are read by name, so older servers are supported
+		        select sch.nspname                                      as table_schema,
+		               tb.relname                                       as table_name,
+		               col.attname                                      as column_name,
+		               nullif(to_jsonb(col) ->> 'attidentity', '')      as identity,
+		               coalesce(to_jsonb(col) ->> 'attgenerated', '') = 's' as generated,
+		               col.attinhcount > 0                              as inherited
+		        from pg_class tb
+		        join pg_namespace sch on sch.oid = tb.relnamespace
+		        join pg_attribute col on col.attrelid = tb.oid
+		        where tb.relkind in ('r', 'p', 'f')
+		          and col.attnum > 0
+		          and not col.attisdropped
+		    ),
+		    owned as (
+		        -- column could own several sequences, pg_get_serial_sequence gets any of them too
+		        select distinct on (table_schema, table_name, column_name)
+		               table_schema, table_name, column_name, sequence
+		        from (` + ownedSequences + `
+		        ) o
+		        order by table_schema, table_name, column_name, sequence
+		    ),
 		    info as (
 				select distinct
 				 	kcu.table_schema as table_schema,
@@ -274,42 +1244,51 @@ func (s store) Columns(tables []table) ([]column, error) {
 		                else 'PRIMARY KEY'=any (i.constraint_types)
 		                end                                    as pk,
 		                'FOREIGN KEY'=any (i.constraint_types) as fk,
-		                c.is_nullable = 'YES'                  as nullable,
-		                c.data_type = 'ARRAY'                  as array,
-		                coalesce(a.array_dims, 0)              as dims,
+		                c.is_nullable and not coalesce(d.not_null, false) as nullable,
+		                c.is_array                             as array,
 		                case
-		                when e.is_enum = true
-		                then 'varchar'
-		                else ltrim(c.udt_name, '_')
-		                end                         as type,
+		                -- attndims is not set for domains over arrays and for columns of views
+		                when c.is_array
+		                then greatest(coalesce(a.array_dims, 0), 1)
+		                else 0
+		                end                                    as dims,
+		                ltrim(coalesce(d.base_type, c.udt_name), '_') as type,
 		                c.column_default            as def,
                         c.character_maximum_length  as len,
-						e.enum_values 				as enum,
-						e.typname					as enumtype
-		from information_schema.tables t
-		left join information_schema.columns c using (table_name, table_schema)
+                        c.numeric_precision         as precision,
+                        c.numeric_scale             as scale,
+						c.column_comment			as comment,
+						d.domain_name				as domain,
+						coalesce(d.base_schema, c.udt_schema) as type_schema,
+						m.identity					as identity,
+						coalesce(m.generated, false) as generated,
+						coalesce(m.inherited, false) as inherited,
+						o.sequence					as sequence
+		from columns c
 		left join info i using (table_name, table_schema, column_name)
 		left join arrays a using (table_name, table_schema, column_name)
-		left join enums e using (table_name, table_schema, column_name)
-		where (t.table_schema, t.table_name) in (?)
-		  and t.table_type = 'BASE TABLE'
+		left join domain_columns d using (table_name, table_schema, column_name)
+		left join managed m using (table_name, table_schema, column_name)
+		left join owned o using (table_name, table_schema, column_name)
+		where (c.table_schema, c.table_name) in (?)
 		order by 1 desc, 2, 3, 5 asc, 6 desc nulls last
 	`
 
-	var columns []column
-	if _, err := s.db.Query(&columns, query, pg.InMulti(ts...)); err != nil {
-		return nil, fmt.Errorf("getting columns info error: %w", err)
+// Columns gets columns of tables, query is selected by source, see QueryColumns
+func (s store) Columns(tables []table) ([]column, error) {
+	ts := make([]interface{}, len(tables))
+	for i, t := range tables {
+		ts[i] = []string{t.Schema, t.Name}
 	}
 
-	for idx, col := range columns {
+	query := columnsCatalog
+	if s.source(QueryColumns) == SourceInformationSchema {
+		query = columnsInformationSchema
+	}
 
-		if _, err := model.GoType(col.Type); err != nil {
-			if dataType, typeValues := s.findEnumDataType(col.Type); dataType != "" {
-				columns[idx].Type = "varchar"
-				columns[idx].Values = typeValues
-				columns[idx].EnumType = dataType
-			}
-		}
+	var columns []column
+	if _, err := s.db.QueryContext(s.ctx, &columns, query, pg.InMulti(ts...)); err != nil {
+		return nil, fmt.Errorf("getting columns info error: %w", err)
 	}
 
 	return columns, nil
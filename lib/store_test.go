@@ -1,10 +1,12 @@
 package genna
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
 	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
 
 	"github.com/go-pg/pg/v9"
 )
@@ -15,7 +17,7 @@ func prepareStore() (*store, error) {
 		return nil, err
 	}
 
-	return newStore(db), nil
+	return newStore(context.Background(), db, nil), nil
 }
 
 func Test_format(t *testing.T) {
@@ -47,8 +49,10 @@ func Test_format(t *testing.T) {
 
 func Test_table_Entity(t *testing.T) {
 	type fields struct {
-		Schema string
-		Name   string
+		Schema       string
+		Name         string
+		Kind         string
+		PartitionKey string
 	}
 	tests := []struct {
 		name   string
@@ -63,12 +67,41 @@ func Test_table_Entity(t *testing.T) {
 			},
 			want: model.NewEntity("public", "users", nil, nil),
 		},
+		{
+			name: "Should create read-only entity for materialized view",
+			fields: fields{
+				Schema: "public",
+				Name:   "user_stats",
+				Kind:   kindMatView,
+			},
+			want: func() model.Entity {
+				entity := model.NewEntity("public", "user_stats", nil, nil)
+				entity.AddView(true)
+				return entity
+			}(),
+		},
+		{
+			name: "Should create entity with partition key",
+			fields: fields{
+				Schema:       "public",
+				Name:         "events",
+				Kind:         kindPartitioned,
+				PartitionKey: "RANGE (created_at)",
+			},
+			want: func() model.Entity {
+				entity := model.NewEntity("public", "events", nil, nil)
+				entity.PartitionKey = "RANGE (created_at)"
+				return entity
+			}(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			z := table{
-				Schema: tt.fields.Schema,
-				Name:   tt.fields.Name,
+				Schema:       tt.fields.Schema,
+				Name:         tt.fields.Name,
+				Kind:         tt.fields.Kind,
+				PartitionKey: tt.fields.PartitionKey,
 			}
 			if got := z.Entity(); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("table.Entity() = %v, want %v", got, tt.want)
@@ -103,7 +136,7 @@ func Test_relation_Relation(t *testing.T) {
 				TargetTable:   "locations",
 				TargetColumns: []string{"locationId"},
 			},
-			want: model.NewRelation([]string{"locationId"}, "geo", "locations"),
+			want: model.NewRelation([]string{"locationId"}, "geo", "locations", []string{"locationId"}),
 		},
 	}
 	for _, tt := range tests {
@@ -175,6 +208,8 @@ func Test_relation_Target(t *testing.T) {
 }
 
 func Test_column_Column(t *testing.T) {
+	mood := model.NewEnum("public", "mood", []string{"sad", "happy"})
+
 	type fields struct {
 		Schema     string
 		Table      string
@@ -187,11 +222,14 @@ func Test_column_Column(t *testing.T) {
 		IsPK       bool
 		IsFK       bool
 		MaxLen     int
-		Values     []string
+		Domain     string
+		Precision  int
+		Scale      int
 	}
 	tests := []struct {
 		name   string
 		fields fields
+		enum   *model.Enum
 		want   model.Column
 	}{
 		{
@@ -208,10 +246,56 @@ func Test_column_Column(t *testing.T) {
 				IsPK:       true,
 				IsFK:       false,
 				MaxLen:     0,
-				Values:     []string{},
 			},
 			want: model.NewColumn("userId", model.TypePGInt8, false, false, false, 0, true, false, 0, "", []string{}, 9),
 		},
+		{
+			name: "Should keep domain name",
+			fields: fields{
+				Schema: "public",
+				Table:  "users",
+				Name:   "email",
+				Type:   model.TypePGText,
+				Domain: "email",
+			},
+			want: func() model.Column {
+				c := model.NewColumn("email", model.TypePGText, false, false, false, 0, false, false, 0, "", []string{}, 9)
+				c.Domain = "email"
+				return c
+			}(),
+		},
+		{
+			name: "Should keep numeric precision",
+			fields: fields{
+				Schema:    "public",
+				Table:     "orders",
+				Name:      "total",
+				Type:      model.TypePGNumeric,
+				Precision: 10,
+				Scale:     2,
+			},
+			want: func() model.Column {
+				c := model.NewColumn("total", model.TypePGNumeric, false, false, false, 0, false, false, 0, "", []string{}, 9)
+				c.Precision, c.Scale = 10, 2
+				return c
+			}(),
+		},
+		{
+			name: "Should store enum as string",
+			fields: fields{
+				Schema:     "public",
+				Table:      "users",
+				Name:       "mood",
+				IsNullable: true,
+				Type:       "mood",
+			},
+			enum: &mood,
+			want: func() model.Column {
+				c := model.NewColumn("mood", model.TypePGVarchar, true, false, false, 0, false, false, 0, "", []string{}, 9)
+				c.AddEnum(&mood)
+				return c
+			}(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -227,15 +311,113 @@ func Test_column_Column(t *testing.T) {
 				IsPK:       tt.fields.IsPK,
 				IsFK:       tt.fields.IsFK,
 				MaxLen:     tt.fields.MaxLen,
-				Values:     tt.fields.Values,
+				Domain:     tt.fields.Domain,
+				Precision:  tt.fields.Precision,
+				Scale:      tt.fields.Scale,
 			}
-			if got := c.Column(false, 9); !reflect.DeepEqual(got, tt.want) {
+			if got := c.Column(tt.enum, false, false, 9); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("column.Column() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+func Test_extensionType(t *testing.T) {
+	extensions := buildExtensions([]extension{
+		{Name: "citext", Schema: "public"},
+		{Name: "postgis", Schema: "gis"},
+	})
+
+	tests := []struct {
+		name   string
+		pgType string
+		schema string
+		want   string
+	}{
+		{
+			name:   "Should map type of installed extension",
+			pgType: model.TypePGCitext,
+			schema: "public",
+			want:   model.TypePGCitext,
+		},
+		{
+			name:   "Should map type of extension installed in other schema",
+			pgType: model.TypePGGeometry,
+			schema: "gis",
+			want:   model.TypePGGeometry,
+		},
+		{
+			name:   "Should not map type of missing extension",
+			pgType: model.TypePGLtree,
+			schema: "public",
+			want:   "public.ltree",
+		},
+		{
+			name:   "Should not map user type named as extension type",
+			pgType: model.TypePGGeography,
+			schema: "public",
+			want:   "public.geography",
+		},
+		{
+			name:   "Should keep built-in type",
+			pgType: model.TypePGText,
+			schema: "pg_catalog",
+			want:   model.TypePGText,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extensionType(tt.pgType, tt.schema, extensions); got != tt.want {
+				t.Errorf("extensionType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sequence_Selected(t *testing.T) {
+	owned := sequence{Schema: "public", Name: "users_id_seq", OwnerSchema: "public", OwnerTable: "users", OwnerColumn: "id"}
+	standalone := sequence{Schema: "billing", Name: "invoice_number"}
+
+	tests := []struct {
+		name     string
+		sequence sequence
+		include  util.Matcher
+		exclude  util.Matcher
+		want     bool
+	}{
+		{
+			name:     "Should select sequence by pattern",
+			sequence: standalone,
+			include:  matcher("billing.*"),
+			want:     true,
+		},
+		{
+			name:     "Should select sequence of selected table",
+			sequence: owned,
+			include:  matcher("public.users"),
+			want:     true,
+		},
+		{
+			name:     "Should not select sequence of other table",
+			sequence: standalone,
+			include:  matcher("public.users"),
+		},
+		{
+			name:     "Should not select sequence of excluded table",
+			sequence: owned,
+			include:  matcher("public.*"),
+			exclude:  matcher("public.users"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sequence.Selected(tt.include, tt.exclude); got != tt.want {
+				t.Errorf("sequence.Selected() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_store_Tables(t *testing.T) {
 	store, err := prepareStore()
 	if err != nil {
@@ -244,7 +426,7 @@ func Test_store_Tables(t *testing.T) {
 	}
 
 	t.Run("Should get all tables from test DB", func(t *testing.T) {
-		tables, err := store.Tables([]string{"public.*", "geo.*"})
+		tables, err := store.Tables(matcher("public.*", "geo.*"), matcher(), false)
 		if err != nil {
 			t.Errorf("get tables error = %v", err)
 			return
@@ -257,7 +439,7 @@ func Test_store_Tables(t *testing.T) {
 	})
 
 	t.Run("Should get specific table from test DB", func(t *testing.T) {
-		tables, err := store.Tables([]string{"public.users"})
+		tables, err := store.Tables(matcher("public.users"), matcher(), false)
 		if err != nil {
 			t.Errorf("get tables error = %v", err)
 			return
@@ -270,7 +452,7 @@ func Test_store_Tables(t *testing.T) {
 	})
 
 	t.Run("Should get specific & geo tables from test DB", func(t *testing.T) {
-		tables, err := store.Tables([]string{"public.users", "geo.*"})
+		tables, err := store.Tables(matcher("public.users", "geo.*"), matcher(), false)
 		if err != nil {
 			t.Errorf("get tables error = %v", err)
 			return
@@ -281,6 +463,24 @@ func Test_store_Tables(t *testing.T) {
 			return
 		}
 	})
+
+	t.Run("Should skip excluded tables", func(t *testing.T) {
+		tables, err := store.Tables(matcher("public.*", "geo.*"), matcher("public.proj*", `re:^geo\.`), false)
+		if err != nil {
+			t.Errorf("get tables error = %v", err)
+			return
+		}
+
+		if ln := len(tables); ln != 1 {
+			t.Errorf("len(Store.Tables()) = %v, want %v", ln, 1)
+			return
+		}
+	})
+}
+
+func matcher(patterns ...string) util.Matcher {
+	m, _ := util.NewMatcher(patterns)
+	return m
 }
 
 func Test_store_Relations(t *testing.T) {
@@ -291,7 +491,7 @@ func Test_store_Relations(t *testing.T) {
 	}
 
 	t.Run("Should get all relations from test DB", func(t *testing.T) {
-		tables, err := store.Tables([]string{"public.*"})
+		tables, err := store.Tables(matcher("public.*"), matcher(), false)
 		if err != nil {
 			t.Errorf("get tables error = %v", err)
 			return
@@ -307,6 +507,10 @@ func Test_store_Relations(t *testing.T) {
 			t.Errorf("len(Store.Relations()) = %v, want %v", ln, 1)
 			return
 		}
+
+		if !relations[0].Primary {
+			t.Errorf("Store.Relations()[0].Primary = %v, want %v", relations[0].Primary, true)
+		}
 	})
 }
 
@@ -318,7 +522,7 @@ func Test_store_Columns(t *testing.T) {
 	}
 
 	t.Run("Should get all columns from test DB", func(t *testing.T) {
-		tables, err := store.Tables([]string{"public.*"})
+		tables, err := store.Tables(matcher("public.*"), matcher(), false)
 		if err != nil {
 			t.Errorf("get tables error = %v", err)
 			return
@@ -335,4 +539,107 @@ func Test_store_Columns(t *testing.T) {
 			return
 		}
 	})
+
+	t.Run("Should get same columns from pg_catalog and information_schema", func(t *testing.T) {
+		tables, err := store.Tables(matcher("*.*"), matcher(), true)
+		if err != nil {
+			t.Errorf("get tables error = %v", err)
+			return
+		}
+
+		catalog, err := newStore(store.ctx, store.db, map[string]string{QueryColumns: SourceCatalog}).Columns(tables)
+		if err != nil {
+			t.Errorf("get columns error = %v", err)
+			return
+		}
+
+		info, err := newStore(store.ctx, store.db, map[string]string{QueryColumns: SourceInformationSchema}).Columns(tables)
+		if err != nil {
+			t.Errorf("get columns error = %v", err)
+			return
+		}
+
+		if !reflect.DeepEqual(catalog, info) {
+			t.Errorf("Store.Columns() from pg_catalog = %+v, from information_schema %+v", catalog, info)
+		}
+	})
+}
+
+const (
+	largeSchemaTables = 4000
+	largeSchemaBatch  = 100
+)
+
+// prepareLargeSchema creates schema of 4000 tables with 15 columns each, like large analytics database
+// every table references previous one, schema should be dropped after use
+func prepareLargeSchema(store *store) ([]table, error) {
+	if _, err := store.db.Exec("create schema bench"); err != nil {
+		return nil, err
+	}
+
+	// every table takes several locks, so tables are created in batches not to exceed max_locks_per_transaction
+	query := `
+		do $$
+		begin
+		    for i in ?..? loop
+		        execute format('
+		            create table bench.t%s (
+		                id         serial primary key,
+		                parent_id  integer references bench.t%s,
+		                name       varchar(64) not null,
+		                code       char(3),
+		                note       text,
+		                amount     numeric(12, 2),
+		                ratio      float8,
+		                total      bigint not null default 0,
+		                active     boolean not null default true,
+		                tags       text[],
+		                attrs      jsonb,
+		                day        date,
+		                created_at timestamptz not null default now(),
+		                updated_at timestamptz,
+		                external   uuid
+		            )', i, greatest(i - 1, 1));
+		    end loop;
+		end
+		$$;
+	`
+
+	for from := 1; from <= largeSchemaTables; from += largeSchemaBatch {
+		if _, err := store.db.Exec(query, from, from+largeSchemaBatch-1); err != nil {
+			return nil, err
+		}
+	}
+
+	return store.Tables(matcher("bench.*"), matcher(), false)
+}
+
+func dropLargeSchema(store *store) {
+	_, _ = store.db.Exec("drop schema if exists bench cascade")
+}
+
+func BenchmarkStore_Columns(b *testing.B) {
+	store, err := prepareStore()
+	if err != nil {
+		b.Fatalf("prepare Store error = %v", err)
+	}
+
+	dropLargeSchema(store)
+	defer dropLargeSchema(store)
+
+	tables, err := prepareLargeSchema(store)
+	if err != nil {
+		b.Fatalf("prepare schema error = %v", err)
+	}
+
+	for _, source := range []string{SourceInformationSchema, SourceCatalog} {
+		b.Run(source, func(b *testing.B) {
+			store.sources = map[string]string{QueryColumns: source}
+			for i := 0; i < b.N; i++ {
+				if _, err := store.Columns(tables); err != nil {
+					b.Fatalf("get columns error = %v", err)
+				}
+			}
+		})
+	}
 }
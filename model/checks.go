@@ -0,0 +1,316 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// CheckCompare is a comparison of column with literal, e.g. price >= 0
+	CheckCompare = "compare"
+	// CheckLength is a comparison of column length with number, e.g. length(code) = 3
+	CheckLength = "length"
+	// CheckIn is a list of allowed values, e.g. status in ('a', 'b')
+	CheckIn = "in"
+	// CheckNotNull is a not null check, e.g. code is not null
+	CheckNotNull = "notnull"
+
+	// CheckValue is a placeholder for column in domain constraints
+	CheckValue = "VALUE"
+)
+
+// CheckRule stores one parsed rule of check constraint
+type CheckRule struct {
+	Column string
+	Kind   string
+
+	// Operator is one of =, <>, <, <=, >, >= for compare and length checks
+	Operator string
+	// Value is a literal for compare and length checks
+	Value string
+	// Values are literals for in checks
+	Values []string
+	// IsString is set if literals are quoted strings
+	IsString bool
+}
+
+var (
+	checkRegEx      = regexp.MustCompile(`(?s)^CHECK\s*\((.*)\)(\s+NO INHERIT)?(\s+NOT VALID)?$`)
+	compareRegEx    = regexp.MustCompile(`(?s)^(.+?)\s+(=|<>|!=|<=|>=|<|>)\s+(.+)$`)
+	notNullRegEx    = regexp.MustCompile(`(?s)^(.+)\s+IS NOT NULL$`)
+	lengthRegEx     = regexp.MustCompile(`(?s)^(length|char_length|character_length)\((.+)\)$`)
+	anyRegEx        = regexp.MustCompile(`(?s)^(.+?)\s+=\s+ANY\s+\((.+)\)$`)
+	arrayRegEx      = regexp.MustCompile(`(?s)^ARRAY\[(.+)\]$`)
+	trailCastRegEx  = regexp.MustCompile(`(?s)^(.+)::[a-zA-Z_][a-zA-Z0-9_ ]*(\(\d+(,\s*\d+)?\))?(\[\])*$`)
+	identRegEx      = regexp.MustCompile(`^([a-z_][a-z0-9_$]*|"([^"]|"")+")$`)
+	numberOnlyRegEx = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+)
+
+// operators reversed, used if literal is on the left side
+var reversed = map[string]string{
+	"=":  "=",
+	"<>": "<>",
+	"<":  ">",
+	"<=": ">=",
+	">":  "<",
+	">=": "<=",
+}
+
+// ParseCheck parses check constraint definition from pg_get_constraintdef
+// returns false if any part of constraint is not supported
+func ParseCheck(def string) ([]CheckRule, bool) {
+	match := checkRegEx.FindStringSubmatch(strings.TrimSpace(def))
+	if match == nil {
+		return nil, false
+	}
+
+	var rules []CheckRule
+	for _, part := range splitAnd(match[1]) {
+		rule, ok := parseRule(part)
+		if !ok {
+			return nil, false
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, len(rules) > 0
+}
+
+func parseRule(s string) (CheckRule, bool) {
+	s = unwrap(s)
+
+	if match := notNullRegEx.FindStringSubmatch(s); match != nil {
+		if column, ok := identifier(match[1]); ok {
+			return CheckRule{Column: column, Kind: CheckNotNull}, true
+		}
+		return CheckRule{}, false
+	}
+
+	if match := anyRegEx.FindStringSubmatch(s); match != nil {
+		column, ok := identifier(match[1])
+		if !ok {
+			return CheckRule{}, false
+		}
+
+		array := arrayRegEx.FindStringSubmatch(uncast(match[2]))
+		if array == nil {
+			return CheckRule{}, false
+		}
+
+		rule := CheckRule{Column: column, Kind: CheckIn}
+		for _, item := range splitTop(array[1], ',') {
+			value, str, ok := literal(item)
+			if !ok {
+				return CheckRule{}, false
+			}
+			rule.Values = append(rule.Values, value)
+			rule.IsString = str
+		}
+
+		return rule, true
+	}
+
+	if match := compareRegEx.FindStringSubmatch(s); match != nil {
+		operator := match[2]
+		if operator == "!=" {
+			operator = "<>"
+		}
+
+		left, right := match[1], match[3]
+		if _, _, ok := literal(left); ok {
+			left, right = right, left
+			operator = reversed[operator]
+		}
+
+		value, str, ok := literal(right)
+		if !ok {
+			return CheckRule{}, false
+		}
+
+		kind := CheckCompare
+		if fn := lengthRegEx.FindStringSubmatch(uncast(left)); fn != nil {
+			if str || !numberOnlyRegEx.MatchString(value) {
+				return CheckRule{}, false
+			}
+			kind, left = CheckLength, fn[2]
+		}
+
+		column, ok := identifier(left)
+		if !ok {
+			return CheckRule{}, false
+		}
+
+		return CheckRule{Column: column, Kind: kind, Operator: operator, Value: value, IsString: str}, true
+	}
+
+	return CheckRule{}, false
+}
+
+// identifier gets column name from expression like ("userId")::text
+func identifier(s string) (string, bool) {
+	s = uncast(s)
+	if s == CheckValue {
+		return s, true
+	}
+
+	if !identRegEx.MatchString(s) {
+		return "", false
+	}
+
+	if strings.HasPrefix(s, `"`) {
+		return strings.Replace(s[1:len(s)-1], `""`, `"`, -1), true
+	}
+
+	return s, true
+}
+
+// literal gets value from expression like ('new'::character varying)::text
+// returns value, is it string and is it literal at all
+func literal(s string) (string, bool, bool) {
+	s = uncast(s)
+
+	switch {
+	case numberOnlyRegEx.MatchString(s):
+		return s, false, true
+	case strings.HasPrefix(s, "'"):
+		if value, rest, ok := unquote(s); ok && rest == "" {
+			if numberOnlyRegEx.MatchString(value) {
+				// negative numbers are quoted by postgres
+				return value, false, true
+			}
+			return value, true, true
+		}
+	}
+
+	return "", false, false
+}
+
+// uncast removes outer parentheses and type casts
+func uncast(s string) string {
+	for {
+		s = unwrap(s)
+
+		match := trailCastRegEx.FindStringSubmatch(s)
+		if match == nil || !balanced(match[1]) {
+			return s
+		}
+
+		s = match[1]
+	}
+}
+
+// unwrap removes parentheses wrapping whole expression
+func unwrap(s string) string {
+	s = strings.TrimSpace(s)
+	for strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") && balanced(s[1:len(s)-1]) {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+
+	return s
+}
+
+// balanced checks that all parentheses and quotes are closed
+func balanced(s string) bool {
+	depth, quoted, dquoted := 0, false, false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\'' && !dquoted:
+			quoted = !quoted
+		case c == '"' && !quoted:
+			dquoted = !dquoted
+		case quoted || dquoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+
+	return depth == 0 && !quoted && !dquoted
+}
+
+// splitAnd splits expression by top level AND
+func splitAnd(s string) []string {
+	s = unwrap(s)
+
+	var parts []string
+	start := 0
+	for i := 0; i+5 <= len(s); i++ {
+		if s[i:i+5] == " AND " && balanced(s[:i]) {
+			parts = append(parts, s[start:i])
+			start = i + 5
+		}
+	}
+
+	if start == 0 {
+		return []string{s}
+	}
+
+	parts = append(parts, s[start:])
+
+	var result []string
+	for _, part := range parts {
+		result = append(result, splitAnd(part)...)
+	}
+
+	return result
+}
+
+// splitTop splits expression by separator outside of parentheses and quotes
+func splitTop(s string, sep byte) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == sep && balanced(s[start:i]) {
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+// AppliesTo checks if rule can be validated for column in go code
+func (r CheckRule) AppliesTo(c Column) bool {
+	if c.IsArray {
+		return false
+	}
+
+	numeric, integer := false, false
+	switch c.GoType {
+	case TypeInt, TypeInt32, TypeInt64:
+		numeric, integer = true, true
+	case TypeFloat32, TypeFloat64, TypeDecimal:
+		numeric = true
+	}
+
+	values := r.Values
+	if r.Kind == CheckCompare {
+		values = []string{r.Value}
+	}
+
+	switch r.Kind {
+	case CheckNotNull:
+		return true
+	case CheckLength:
+		return c.GoType == TypeString
+	case CheckCompare, CheckIn:
+		if r.IsString {
+			return c.GoType == TypeString && (r.Kind == CheckIn || r.Operator == "=" || r.Operator == "<>")
+		}
+		if !numeric {
+			return false
+		}
+		for _, value := range values {
+			if integer && strings.Contains(value, ".") {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,177 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		def    string
+		want   []CheckRule
+		wantOK bool
+	}{
+		{
+			name:   "Should parse comparison",
+			def:    "CHECK ((price >= (0)::numeric))",
+			want:   []CheckRule{{Column: "price", Kind: CheckCompare, Operator: ">=", Value: "0"}},
+			wantOK: true,
+		},
+		{
+			name:   "Should parse reversed comparison",
+			def:    `CHECK ((0 < "unitPrice"))`,
+			want:   []CheckRule{{Column: "unitPrice", Kind: CheckCompare, Operator: ">", Value: "0"}},
+			wantOK: true,
+		},
+		{
+			name:   "Should parse negative number",
+			def:    "CHECK ((delta > '-10'::integer))",
+			want:   []CheckRule{{Column: "delta", Kind: CheckCompare, Operator: ">", Value: "-10"}},
+			wantOK: true,
+		},
+		{
+			name: "Should parse between",
+			def:  "CHECK (((rating >= 1) AND (rating <= 5)))",
+			want: []CheckRule{
+				{Column: "rating", Kind: CheckCompare, Operator: ">=", Value: "1"},
+				{Column: "rating", Kind: CheckCompare, Operator: "<=", Value: "5"},
+			},
+			wantOK: true,
+		},
+		{
+			name:   "Should parse length",
+			def:    "CHECK ((length((code)::text) = 3))",
+			want:   []CheckRule{{Column: "code", Kind: CheckLength, Operator: "=", Value: "3"}},
+			wantOK: true,
+		},
+		{
+			name:   "Should parse char_length",
+			def:    "CHECK ((char_length(name) <= 10))",
+			want:   []CheckRule{{Column: "name", Kind: CheckLength, Operator: "<=", Value: "10"}},
+			wantOK: true,
+		},
+		{
+			name:   "Should parse in list",
+			def:    "CHECK ((status = ANY (ARRAY['a'::text, 'b''c'::text])))",
+			want:   []CheckRule{{Column: "status", Kind: CheckIn, Values: []string{"a", "b'c"}, IsString: true}},
+			wantOK: true,
+		},
+		{
+			name:   "Should parse in list with varchar casts",
+			def:    "CHECK (((status)::text = ANY ((ARRAY['a'::character varying, 'b'::character varying])::text[])))",
+			want:   []CheckRule{{Column: "status", Kind: CheckIn, Values: []string{"a", "b"}, IsString: true}},
+			wantOK: true,
+		},
+		{
+			name:   "Should parse is not null",
+			def:    "CHECK ((code IS NOT NULL)) NOT VALID",
+			want:   []CheckRule{{Column: "code", Kind: CheckNotNull}},
+			wantOK: true,
+		},
+		{
+			name:   "Should parse domain value",
+			def:    "CHECK ((VALUE ~~ '%@%'::citext))",
+			wantOK: false,
+		},
+		{
+			name:   "Should parse domain comparison",
+			def:    "CHECK ((VALUE > 0))",
+			want:   []CheckRule{{Column: "VALUE", Kind: CheckCompare, Operator: ">", Value: "0"}},
+			wantOK: true,
+		},
+		{
+			name:   "Should not parse or",
+			def:    "CHECK (((price > 0) OR (discount > 0)))",
+			wantOK: false,
+		},
+		{
+			name:   "Should not parse column comparison",
+			def:    `CHECK (("startAt" < "endAt"))`,
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseCheck(tt.def)
+			if ok != tt.wantOK {
+				t.Errorf("ParseCheck() ok = %v, want %v", ok, tt.wantOK)
+				return
+			}
+			if ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCheck() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckRule_AppliesTo(t *testing.T) {
+	text := NewColumn("code", TypePGText, false, false, false, 0, false, false, 0, "", []string{}, 9)
+	integer := NewColumn("count", TypePGInt4, false, false, false, 0, false, false, 0, "", []string{}, 9)
+	array := NewColumn("tags", TypePGText, false, false, true, 1, false, false, 0, "", []string{}, 9)
+	exact := NewColumn("price", TypePGNumeric, false, false, false, 0, false, false, 0, "", []string{}, 9)
+	exact.UseDecimal()
+
+	tests := []struct {
+		name   string
+		rule   CheckRule
+		column Column
+		want   bool
+	}{
+		{
+			name:   "Should apply length to string",
+			rule:   CheckRule{Kind: CheckLength, Operator: "=", Value: "3"},
+			column: text,
+			want:   true,
+		},
+		{
+			name:   "Should not apply length to number",
+			rule:   CheckRule{Kind: CheckLength, Operator: "=", Value: "3"},
+			column: integer,
+			want:   false,
+		},
+		{
+			name:   "Should apply number comparison",
+			rule:   CheckRule{Kind: CheckCompare, Operator: ">=", Value: "0"},
+			column: integer,
+			want:   true,
+		},
+		{
+			name:   "Should not apply fraction to integer",
+			rule:   CheckRule{Kind: CheckCompare, Operator: ">=", Value: "0.5"},
+			column: integer,
+			want:   false,
+		},
+		{
+			name:   "Should apply fraction to decimal",
+			rule:   CheckRule{Kind: CheckCompare, Operator: ">=", Value: "0.5"},
+			column: exact,
+			want:   true,
+		},
+		{
+			name:   "Should not apply string ordering",
+			rule:   CheckRule{Kind: CheckCompare, Operator: ">", Value: "a", IsString: true},
+			column: text,
+			want:   false,
+		},
+		{
+			name:   "Should apply string list",
+			rule:   CheckRule{Kind: CheckIn, Values: []string{"a", "b"}, IsString: true},
+			column: text,
+			want:   true,
+		},
+		{
+			name:   "Should not apply to array",
+			rule:   CheckRule{Kind: CheckLength, Operator: "=", Value: "3"},
+			column: array,
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.AppliesTo(tt.column); got != tt.want {
+				t.Errorf("CheckRule.AppliesTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"strings"
+
 	"github.com/dizzyfool/genna/util"
 )
 
@@ -23,13 +25,58 @@ type Column struct {
 	IsFK     bool
 	Relation *Relation
 
+	// IsUnique is set if column has its own unique index
+	IsUnique bool
+	// IsInherited is set if column is inherited from parent table
+	IsInherited bool
+
 	Import string
 
-	MaxLen   int
+	MaxLen int
+
+	// Precision and Scale are set for numeric columns with declared precision
+	Precision int
+	Scale     int
+
+	// EnumType is a full name of enum type, Values are its labels
 	EnumType string
 	Values   []string
+	// Enum is set for columns of user-defined enum types
+	Enum *Enum
+
+	// Composite is set for columns of user-defined composite types
+	Composite *Composite
+
+	// Domain is a name of domain type, column type is resolved to its base type
+	Domain string
+
+	// Description is a column comment from database
+	Description string
+
+	// Default is a raw default expression, DefaultKind is its classification
+	// DefaultValue is set for literal defaults only
+	Default      string
+	DefaultKind  string
+	DefaultValue string
+
+	// Checks are rules parsed from check constraints
+	Checks []CheckRule
+
+	// Identity is set for identity columns, see IdentityAlways and IdentityByDefault
+	Identity string
+	// Generated is set for generated stored columns
+	Generated bool
+	// Sequence is a sequence owned by column, set for serial and identity columns
+	Sequence string
 }
 
+const (
+	// IdentityAlways is a generated always as identity column
+	IdentityAlways = "a"
+	// IdentityByDefault is a generated by default as identity column
+	IdentityByDefault = "d"
+)
+
 // NewColumn creates Column from pg info
 func NewColumn(pgName string, pgType string, nullable, sqlNulls, array bool, dims int, pk, fk bool, len int, enumType string, values []string, goPGVer int) Column {
 	var err error
@@ -56,9 +103,9 @@ func NewColumn(pgName string, pgType string, nullable, sqlNulls, array bool, dim
 
 	switch {
 	case column.IsArray:
-		column.Type, err = GoSlice(pgType, dims)
+		column.Type, err = GoSlice(pgType, dims, false)
 	case column.Nullable:
-		column.Type, err = GoNullable(pgType, sqlNulls)
+		column.Type, err = GoNullable(pgType, sqlNulls, false)
 	default:
 		column.Type = column.GoType
 	}
@@ -67,12 +114,85 @@ func NewColumn(pgName string, pgType string, nullable, sqlNulls, array bool, dim
 		column.Type = column.GoType
 	}
 
-	column.Import = GoImport(pgType, nullable, sqlNulls, goPGVer)
+	column.Import = GoImport(pgType, nullable, sqlNulls, false, goPGVer)
 
 	return column
 }
 
+// UseDecimal maps numeric column to exact decimal type. Should be used after NewColumn
+func (c *Column) UseDecimal() {
+	if c.PGType != TypePGNumeric {
+		return
+	}
+
+	// sql.NullFloat64 is used only if sql nulls are set
+	sqlNulls := c.Type == "sql.NullFloat64"
+
+	c.GoType = TypeDecimal
+	switch {
+	case c.IsArray:
+		c.Type, _ = GoSlice(c.PGType, c.Dimensions, true)
+	case c.Nullable:
+		c.Type, _ = GoNullable(c.PGType, sqlNulls, true)
+	default:
+		c.Type = c.GoType
+	}
+
+	c.Import = GoImport(c.PGType, c.Nullable, sqlNulls, true, 0)
+}
+
+// AddDefault adds default expression to column
+func (c *Column) AddDefault(def string) {
+	c.DefaultKind, c.DefaultValue = ParseDefault(def)
+	if c.DefaultKind != "" {
+		c.Default = def
+	}
+}
+
+// HasDefault checks if value could be filled by database
+func (c Column) HasDefault() bool {
+	return c.DefaultKind != ""
+}
+
+// IsSerial checks if column is serial, e.g. owns sequence but not identity
+func (c Column) IsSerial() bool {
+	return c.Sequence != "" && c.Identity == ""
+}
+
+// IsManaged checks if column value is filled by database
+func (c Column) IsManaged() bool {
+	return c.Identity != "" || c.Generated || c.IsSerial()
+}
+
+// IsGeneratedAlways checks if column value can not be written at all
+func (c Column) IsGeneratedAlways() bool {
+	return c.Identity == IdentityAlways || c.Generated
+}
+
 // AddRelation adds relation to column. Should be used if FK
 func (c *Column) AddRelation(relation *Relation) {
 	c.Relation = relation
 }
+
+// AddEnum sets enum type of column, values are stored as strings
+func (c *Column) AddEnum(enum *Enum) {
+	c.Enum = enum
+	c.EnumType = enum.PGFullName
+	c.Values = enum.Values
+}
+
+// AddComposite sets composite struct as column type
+func (c *Column) AddComposite(composite *Composite) {
+	c.Composite = composite
+	c.GoType = composite.GoName
+	c.Import = ""
+
+	switch {
+	case c.IsArray:
+		c.Type = strings.Repeat("[]", c.Dimensions) + c.GoType
+	case c.Nullable:
+		c.Type = "*" + c.GoType
+	default:
+		c.Type = c.GoType
+	}
+}
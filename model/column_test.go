@@ -53,6 +53,7 @@ func TestColumn_GoType(t *testing.T) {
 		dims     int
 		nullable bool
 		sqlNulls bool
+		decimal  bool
 	}
 	tests := []struct {
 		name   string
@@ -120,13 +121,140 @@ func TestColumn_GoType(t *testing.T) {
 			},
 			want: "interface{}",
 		},
+		{
+			name: "Should generate decimal type",
+			fields: fields{
+				pgType:  TypePGNumeric,
+				decimal: true,
+			},
+			want: "decimal.Decimal",
+		},
+		{
+			name: "Should generate nullable decimal type",
+			fields: fields{
+				pgType:   TypePGNumeric,
+				nullable: true,
+				decimal:  true,
+			},
+			want: "*decimal.Decimal",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := NewColumn("test", tt.fields.pgType, tt.fields.nullable, tt.fields.sqlNulls, tt.fields.array, tt.fields.dims, false, false, 0, "", []string{}, 9)
+			if tt.fields.decimal {
+				c.UseDecimal()
+			}
 			if got := c.Type; got != tt.want {
 				t.Errorf("Column.Type = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestColumn_AddDefault(t *testing.T) {
+	t.Run("Should add function default", func(t *testing.T) {
+		c := NewColumn("createdAt", TypePGTimestamptz, false, false, false, 0, false, false, 0, "", []string{}, 9)
+		c.AddDefault("now()")
+		if !c.HasDefault() || c.Default != "now()" || c.DefaultKind != DefaultFunction {
+			t.Errorf("Column.AddDefault() = %v, %v, want %v, %v", c.Default, c.DefaultKind, "now()", DefaultFunction)
+		}
+	})
+
+	t.Run("Should ignore null default", func(t *testing.T) {
+		c := NewColumn("name", TypePGText, true, false, false, 0, false, false, 0, "", []string{}, 9)
+		c.AddDefault("NULL::text")
+		if c.HasDefault() || c.Default != "" {
+			t.Errorf("Column.AddDefault() = %v, %v, want no default", c.Default, c.DefaultKind)
+		}
+	})
+}
+
+func TestColumn_AddComposite(t *testing.T) {
+	composite := NewComposite("public", "address", nil)
+
+	tests := []struct {
+		name     string
+		nullable bool
+		array    bool
+		dims     int
+		want     string
+	}{
+		{
+			name: "Should use struct type",
+			want: "PublicAddress",
+		},
+		{
+			name:     "Should use pointer for nullable",
+			nullable: true,
+			want:     "*PublicAddress",
+		},
+		{
+			name:     "Should use slice for array",
+			nullable: true,
+			array:    true,
+			dims:     1,
+			want:     "[]PublicAddress",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewColumn("address", "address", tt.nullable, false, tt.array, tt.dims, false, false, 0, "", []string{}, 9)
+			c.AddComposite(&composite)
+			if c.Type != tt.want || c.GoType != composite.GoName {
+				t.Errorf("Column.Type = %v, want %v", c.Type, tt.want)
+			}
+		})
+	}
+}
+
+func TestColumn_IsManaged(t *testing.T) {
+	tests := []struct {
+		name       string
+		identity   string
+		generated  bool
+		sequence   string
+		wantSerial bool
+		wantAlways bool
+		want       bool
+	}{
+		{
+			name: "Should not be managed",
+		},
+		{
+			name:       "Should detect serial",
+			sequence:   "public.users_id_seq",
+			wantSerial: true,
+			want:       true,
+		},
+		{
+			name:       "Should detect identity always",
+			identity:   IdentityAlways,
+			sequence:   "public.users_id_seq",
+			wantAlways: true,
+			want:       true,
+		},
+		{
+			name:     "Should detect identity by default",
+			identity: IdentityByDefault,
+			sequence: "public.users_id_seq",
+			want:     true,
+		},
+		{
+			name:       "Should detect generated column",
+			generated:  true,
+			wantAlways: true,
+			want:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewColumn("id", TypePGInt8, false, false, false, 0, false, false, 0, "", []string{}, 9)
+			c.Identity, c.Generated, c.Sequence = tt.identity, tt.generated, tt.sequence
+			if c.IsManaged() != tt.want || c.IsSerial() != tt.wantSerial || c.IsGeneratedAlways() != tt.wantAlways {
+				t.Errorf("Column.IsManaged(), IsSerial(), IsGeneratedAlways() = %v, %v, %v, want %v, %v, %v",
+					c.IsManaged(), c.IsSerial(), c.IsGeneratedAlways(), tt.want, tt.wantSerial, tt.wantAlways)
+			}
+		})
+	}
+}
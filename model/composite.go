@@ -0,0 +1,60 @@
+package model
+
+import (
+	"github.com/dizzyfool/genna/util"
+)
+
+// Composite stores information about user-defined composite type
+type Composite struct {
+	GoName     string
+	PGName     string
+	PGSchema   string
+	PGFullName string
+
+	// Fields are composite attributes in order of declaration
+	Fields []Column
+
+	Imports []string
+}
+
+// NewComposite creates Composite from pg info
+func NewComposite(schema, pgName string, fields []Column) Composite {
+	composite := Composite{
+		GoName:     util.CamelCased(schema) + util.CamelCased(util.Sanitize(pgName)),
+		PGName:     pgName,
+		PGSchema:   schema,
+		PGFullName: util.Join(schema, pgName),
+
+		Fields:  []Column{},
+		Imports: []string{},
+	}
+
+	for _, field := range fields {
+		composite.AddField(field)
+	}
+
+	return composite
+}
+
+// AddField adds attribute to composite
+func (c *Composite) AddField(field Column) {
+	c.Fields = append(c.Fields, field)
+
+	if field.Import != "" {
+		for _, imp := range c.Imports {
+			if imp == field.Import {
+				return
+			}
+		}
+		c.Imports = append(c.Imports, field.Import)
+	}
+}
+
+// TypeName gets type name usable in sql, public schema is omitted
+func (c Composite) TypeName() string {
+	if c.PGSchema == util.PublicSchema {
+		return c.PGName
+	}
+
+	return c.PGFullName
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/dizzyfool/genna/util"
+)
+
+func TestNewComposite(t *testing.T) {
+	tests := []struct {
+		name        string
+		schema      string
+		pgName      string
+		wantGoName  string
+		wantType    string
+		wantImports int
+	}{
+		{
+			name:        "Should generate from public type",
+			schema:      util.PublicSchema,
+			pgName:      "address",
+			wantGoName:  "PublicAddress",
+			wantType:    "address",
+			wantImports: 1,
+		},
+		{
+			name:        "Should generate from custom schema",
+			schema:      "geo",
+			pgName:      "lat_lng",
+			wantGoName:  "GeoLatLng",
+			wantType:    "geo.lat_lng",
+			wantImports: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := []Column{
+				NewColumn("street", TypePGText, true, false, false, 0, false, false, 0, "", []string{}, 9),
+				NewColumn("validFrom", TypePGTimestamptz, true, false, false, 0, false, false, 0, "", []string{}, 9),
+				NewColumn("validTo", TypePGTimestamptz, true, false, false, 0, false, false, 0, "", []string{}, 9),
+			}
+			c := NewComposite(tt.schema, tt.pgName, fields)
+			if c.GoName != tt.wantGoName || c.TypeName() != tt.wantType || len(c.Imports) != tt.wantImports {
+				t.Errorf("NewComposite() = %v, %v, %v, want %v, %v, %v", c.GoName, c.TypeName(), len(c.Imports), tt.wantGoName, tt.wantType, tt.wantImports)
+			}
+		})
+	}
+}
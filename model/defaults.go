@@ -0,0 +1,69 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// DefaultSequence is a default filled by sequence, e.g. serial columns
+	DefaultSequence = "sequence"
+	// DefaultLiteral is a constant default, e.g. 'new'::text or 0
+	DefaultLiteral = "literal"
+	// DefaultFunction is a default calculated by database, e.g. now()
+	DefaultFunction = "function"
+)
+
+var (
+	castRegEx    = regexp.MustCompile(`^(::[a-zA-Z_][a-zA-Z0-9_ ."]*(\(\d+(,\s*\d+)?\))?(\[\])*)*$`)
+	numberRegEx  = regexp.MustCompile(`^\(?-?\d+(\.\d+)?\)?$`)
+	nullRegEx    = regexp.MustCompile(`(?i)^null(::.*)?$`)
+	booleanRegEx = regexp.MustCompile(`(?i)^(true|false)$`)
+)
+
+// ParseDefault classifies column default expression
+// returns kind of default and literal value without quotes and casts
+// both are empty if column has no default
+func ParseDefault(def string) (kind, value string) {
+	def = strings.TrimSpace(def)
+
+	switch {
+	case def == "", nullRegEx.MatchString(def):
+		return "", ""
+	case strings.HasPrefix(strings.ToLower(def), "nextval("):
+		return DefaultSequence, ""
+	case numberRegEx.MatchString(def):
+		return DefaultLiteral, strings.Trim(def, "()")
+	case booleanRegEx.MatchString(def):
+		return DefaultLiteral, strings.ToLower(def)
+	case strings.HasPrefix(def, "'"):
+		if value, rest, ok := unquote(def); ok && castRegEx.MatchString(rest) {
+			return DefaultLiteral, value
+		}
+	}
+
+	return DefaultFunction, ""
+}
+
+// unquote reads quoted string from the beginning of s
+// returns unescaped string and the rest of s
+func unquote(s string) (string, string, bool) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		if s[i] != '\'' {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		// escaped quote
+		if i+1 < len(s) && s[i+1] == '\'' {
+			b.WriteByte('\'')
+			i++
+			continue
+		}
+
+		return b.String(), s[i+1:], true
+	}
+
+	return "", "", false
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestParseDefault(t *testing.T) {
+	tests := []struct {
+		name      string
+		def       string
+		wantKind  string
+		wantValue string
+	}{
+		{
+			name: "Should parse empty default",
+			def:  "",
+		},
+		{
+			name: "Should parse null default",
+			def:  "NULL::character varying",
+		},
+		{
+			name:     "Should parse serial",
+			def:      "nextval('users_id_seq'::regclass)",
+			wantKind: DefaultSequence,
+		},
+		{
+			name:      "Should parse number",
+			def:       "0",
+			wantKind:  DefaultLiteral,
+			wantValue: "0",
+		},
+		{
+			name:      "Should parse negative number",
+			def:       "(-1.5)",
+			wantKind:  DefaultLiteral,
+			wantValue: "-1.5",
+		},
+		{
+			name:      "Should parse boolean",
+			def:       "false",
+			wantKind:  DefaultLiteral,
+			wantValue: "false",
+		},
+		{
+			name:      "Should parse string with cast",
+			def:       "'it''s new'::character varying",
+			wantKind:  DefaultLiteral,
+			wantValue: "it's new",
+		},
+		{
+			name:      "Should parse string with array cast",
+			def:       "'{}'::integer[]",
+			wantKind:  DefaultLiteral,
+			wantValue: "{}",
+		},
+		{
+			name:     "Should parse function call",
+			def:      "now()",
+			wantKind: DefaultFunction,
+		},
+		{
+			name:     "Should parse expression with strings",
+			def:      "('a'::text || 'b'::text)",
+			wantKind: DefaultFunction,
+		},
+		{
+			name:     "Should parse string concatenation",
+			def:      "'a'::text || 'b'::text",
+			wantKind: DefaultFunction,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, value := ParseDefault(tt.def)
+			if kind != tt.wantKind {
+				t.Errorf("ParseDefault() kind = %v, want %v", kind, tt.wantKind)
+			}
+			if value != tt.wantValue {
+				t.Errorf("ParseDefault() value = %v, want %v", value, tt.wantValue)
+			}
+		})
+	}
+}
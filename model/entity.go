@@ -14,18 +14,54 @@ type Entity struct {
 	PGSchema     string
 	PGFullName   string
 
+	// Description is a table comment from database
+	Description string
+
+	// ViewName is set for views and materialized views
 	ViewName string
+	// ReadOnly entities should not be written to
+	ReadOnly bool
+	// Materialized is set for materialized views
+	Materialized bool
+	// Junction is set for tables linking two other tables as many-to-many
+	Junction bool
+	// Foreign is set for foreign tables
+	Foreign bool
+	// Parents are tables this one inherits from
+	Parents []*Entity
+	// IsParent is set if other tables inherit this one
+	IsParent bool
+
+	// PartitionKey is a partition key definition of partitioned table, e.g. RANGE ("createdAt")
+	PartitionKey string
 
 	Columns   []Column
 	Relations []Relation
 
+	// InverseRelations are relations from other entities referencing this one
+	InverseRelations []Relation
+
+	// Indexes are all indexes of the table including primary key
+	// UniqueKeys are unique indexes and constraints except primary key
+	Indexes    []Index
+	UniqueKeys []Index
+
+	// UnsupportedChecks are check constraints that can not be translated to validation rules
+	UnsupportedChecks []string
+
 	Imports []string
-	Enums   []util.Enum
+
+	// Enums are enum types used by columns
+	Enums []Enum
+
+	// Composites are composite types used by columns, including nested ones
+	Composites []Composite
 
 	// helper indexes
 	colIndex util.Index
 	impIndex map[string]struct{}
 	enmIndex map[string]struct{}
+	cmpIndex map[string]struct{}
 }
 
 // NewEntity creates new Entity from pg info
@@ -47,14 +83,19 @@ func NewEntity(schema, pgName string, columns []Column, relations []Relation) En
 		PGFullName:   util.JoinF(util.SchemaNameInFull(schema), pgName),
 		// PGFullName: util.JoinF(schema, pgName),
 
-		Columns:   []Column{},
-		Relations: []Relation{},
-		colIndex:  util.NewIndex(),
+		Columns:          []Column{},
+		Relations:        []Relation{},
+		InverseRelations: []Relation{},
+		Indexes:          []Index{},
+		UniqueKeys:       []Index{},
+		colIndex:         util.NewIndex(),
 
-		Imports:  []string{},
-		Enums:    []util.Enum{},
-		impIndex: map[string]struct{}{},
-		enmIndex: map[string]struct{}{},
+		Imports:    []string{},
+		Enums:      []Enum{},
+		Composites: []Composite{},
+		impIndex:   map[string]struct{}{},
+		enmIndex:   map[string]struct{}{},
+		cmpIndex:   map[string]struct{}{},
 	}
 
 	if columns != nil {
@@ -72,6 +113,44 @@ func NewEntity(schema, pgName string, columns []Column, relations []Relation) En
 	return entity
 }
 
+// AddView marks entity as read-only view
+func (e *Entity) AddView(materialized bool) {
+	e.ViewName = e.PGName
+	e.ReadOnly = true
+	e.Materialized = materialized
+}
+
+// IsView checks if entity is a view or materialized view
+func (e *Entity) IsView() bool {
+	return e.ViewName != ""
+}
+
+// KeepDigits sets go name keeping digits of table name, e.g. for partitions events_2023 and events_2024
+func (e *Entity) KeepDigits() {
+	e.GoName = util.CamelCased(e.PGSchema) + util.EntityName(e.PGName)
+}
+
+// AddParent adds parent table. Inherited columns are expected to be found in parent
+func (e *Entity) AddParent(parent *Entity) {
+	parent.IsParent = true
+	e.Parents = append(e.Parents, parent)
+}
+
+// HasRelations checks if entity has any relations, including inverse
+func (e *Entity) HasRelations() bool {
+	return len(e.Relations) > 0 || len(e.InverseRelations) > 0
+}
+
+// HasParents checks if entity inherits other tables
+func (e *Entity) HasParents() bool {
+	return len(e.Parents) > 0
+}
+
+// IsPartitioned checks if entity is a partitioned table
+func (e *Entity) IsPartitioned() bool {
+	return e.PartitionKey != ""
+}
+
 // AddColumn adds column to entity
 func (e *Entity) AddColumn(column Column) {
 	if !e.colIndex.Available(column.GoName) {
@@ -88,16 +167,42 @@ func (e *Entity) AddColumn(column Column) {
 		}
 	}
 
-	if enm := column.EnumType; enm != "" {
-		if _, ok := e.enmIndex[enm]; !ok {
-			e.enmIndex[enm] = struct{}{}
-			e.Enums = append(e.Enums, util.Enum{
-				Name:    enm,
-				Values:  column.Values,
-				Entries: make([]util.EnumEntries, 0),
-			})
+	if column.Composite != nil {
+		e.addComposite(*column.Composite)
+	}
+
+	if column.Enum != nil {
+		e.addEnum(*column.Enum)
+	}
+}
+
+// addEnum adds enum once
+func (e *Entity) addEnum(enum Enum) {
+	if _, ok := e.enmIndex[enum.PGFullName]; ok {
+		return
+	}
+	e.enmIndex[enum.PGFullName] = struct{}{}
+
+	e.Enums = append(e.Enums, enum)
+}
+
+// addComposite adds composite with all nested composites
+func (e *Entity) addComposite(composite Composite) {
+	if _, ok := e.cmpIndex[composite.PGFullName]; ok {
+		return
+	}
+	e.cmpIndex[composite.PGFullName] = struct{}{}
+
+	for _, field := range composite.Fields {
+		if field.Composite != nil {
+			e.addComposite(*field.Composite)
+		}
+		if field.Enum != nil {
+			e.addEnum(*field.Enum)
 		}
 	}
+
+	e.Composites = append(e.Composites, composite)
 }
 
 // AddRelation adds relation to entity
@@ -119,6 +224,89 @@ func (e *Entity) AddRelation(relation Relation) {
 	}
 }
 
+// AddInverseRelation adds relation from other entity to this one
+func (e *Entity) AddInverseRelation(relation Relation) {
+	if !e.colIndex.Available(relation.GoName) {
+		relation.GoName = e.colIndex.GetNext(relation.GoName + util.Rel)
+	}
+	e.colIndex.Add(relation.GoName)
+
+	e.InverseRelations = append(e.InverseRelations, relation)
+}
+
+// AddIndex adds index to entity. Should be used after columns added
+func (e *Entity) AddIndex(index Index) {
+	e.Indexes = append(e.Indexes, index)
+
+	if !index.IsUnique || index.IsPrimary {
+		return
+	}
+
+	e.UniqueKeys = append(e.UniqueKeys, index)
+
+	if index.IsSimple() && len(index.Columns) == 1 {
+		for i, column := range e.Columns {
+			if column.PGName == index.Columns[0] {
+				e.Columns[i].IsUnique = true
+			}
+		}
+	}
+}
+
+// AddCheck adds check constraint rules to entity columns. Should be used after columns added
+func (e *Entity) AddCheck(name, def string) {
+	rules, ok := ParseCheck(def)
+	if !ok {
+		e.UnsupportedChecks = append(e.UnsupportedChecks, name+": "+def)
+		return
+	}
+
+	e.addRules(name+": "+def, rules)
+}
+
+// AddDomainCheck adds check constraint of column domain. Should be used after columns added
+func (e *Entity) AddDomainCheck(column, name, def string) {
+	rules, ok := ParseCheck(def)
+	if !ok {
+		e.UnsupportedChecks = append(e.UnsupportedChecks, name+": "+def)
+		return
+	}
+
+	for i, rule := range rules {
+		if rule.Column != CheckValue {
+			e.UnsupportedChecks = append(e.UnsupportedChecks, name+": "+def)
+			return
+		}
+		rules[i].Column = column
+	}
+
+	e.addRules(name+": "+def, rules)
+}
+
+func (e *Entity) addRules(check string, rules []CheckRule) {
+	// all columns should be found and all rules should apply before adding any rule
+	indexes := make([]int, len(rules))
+	for r, rule := range rules {
+		indexes[r] = -1
+		for i, column := range e.Columns {
+			if column.PGName == rule.Column {
+				indexes[r] = i
+				break
+			}
+		}
+
+		// rules not validated in go code are reported too
+		if indexes[r] == -1 || !rule.AppliesTo(e.Columns[indexes[r]]) {
+			e.UnsupportedChecks = append(e.UnsupportedChecks, check)
+			return
+		}
+	}
+
+	for r, rule := range rules {
+		e.Columns[indexes[r]].Checks = append(e.Columns[indexes[r]].Checks, rule)
+	}
+}
+
 // HasMultiplePKs checks if entity has many primary keys
 func (e *Entity) HasMultiplePKs() bool {
 	counter := 0
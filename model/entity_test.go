@@ -114,14 +114,14 @@ func TestEntity_AddColumn(t *testing.T) {
 
 func TestEntity_AddRelation(t *testing.T) {
 	column1 := NewColumn("test", TypePGText, false, false, false, 0, false, false, 0, "", []string{}, 9)
-	relation1 := NewRelation([]string{"userId"}, util.PublicSchema, "users")
+	relation1 := NewRelation([]string{"userId"}, util.PublicSchema, "users", []string{"userId"})
 
 	entity := NewEntity(util.PublicSchema, "test", []Column{column1}, []Relation{relation1})
 
 	t.Run("Should add column", func(t *testing.T) {
-		relation2 := NewRelation([]string{"locationId"}, util.PublicSchema, "locations")
-		relation3 := NewRelation([]string{"testId"}, util.PublicSchema, "tests")
-		relation4 := NewRelation([]string{"testId"}, util.PublicSchema, "tests_")
+		relation2 := NewRelation([]string{"locationId"}, util.PublicSchema, "locations", []string{"locationId"})
+		relation3 := NewRelation([]string{"testId"}, util.PublicSchema, "tests", []string{"testId"})
+		relation4 := NewRelation([]string{"testId"}, util.PublicSchema, "tests_", []string{"testId"})
 
 		t.Run("Should add second relation", func(t *testing.T) {
 			entity.AddRelation(relation2)
@@ -174,3 +174,162 @@ func TestEntity_HasMultiplePKs(t *testing.T) {
 		})
 	})
 }
+
+func TestEntity_AddView(t *testing.T) {
+	t.Run("Should mark view as read-only", func(t *testing.T) {
+		entity := NewEntity(util.PublicSchema, "user_stats", nil, nil)
+		entity.AddView(false)
+		if !entity.IsView() || !entity.ReadOnly || entity.Materialized {
+			t.Errorf("Entity.AddView(false) = %v, %v, %v, want %v, %v, %v", entity.IsView(), entity.ReadOnly, entity.Materialized, true, true, false)
+		}
+	})
+
+	t.Run("Should mark materialized view", func(t *testing.T) {
+		entity := NewEntity(util.PublicSchema, "user_stats", nil, nil)
+		entity.AddView(true)
+		if !entity.IsView() || !entity.ReadOnly || !entity.Materialized {
+			t.Errorf("Entity.AddView(true) = %v, %v, %v, want %v, %v, %v", entity.IsView(), entity.ReadOnly, entity.Materialized, true, true, true)
+		}
+	})
+}
+
+func TestEntity_AddInverseRelation(t *testing.T) {
+	column1 := NewColumn("users", TypePGText, false, false, false, 0, false, false, 0, "", []string{}, 9)
+	entity := NewEntity(util.PublicSchema, "countries", []Column{column1}, nil)
+
+	t.Run("Should add inverse relation with same name as column", func(t *testing.T) {
+		entity.AddInverseRelation(NewInverseRelation([]string{"countryId"}, util.PublicSchema, "users", []string{"countryId"}, false))
+		if len(entity.InverseRelations) != 1 {
+			t.Errorf("Entity.InverseRelations = %v, want %v", len(entity.InverseRelations), 1)
+		}
+		if entity.InverseRelations[0].GoName != "UsersRel" {
+			t.Errorf("Entity.InverseRelations[0].GoName = %v, want %v", entity.InverseRelations[0].GoName, "UsersRel")
+		}
+	})
+
+	t.Run("Should add second inverse relation from same table", func(t *testing.T) {
+		entity.AddInverseRelation(NewInverseRelation([]string{"birthCountryId"}, util.PublicSchema, "users", []string{"countryId"}, false))
+		if entity.InverseRelations[1].GoName != "UsersRel1" {
+			t.Errorf("Entity.InverseRelations[1].GoName = %v, want %v", entity.InverseRelations[1].GoName, "UsersRel1")
+		}
+	})
+}
+
+func TestEntity_AddIndex(t *testing.T) {
+	column1 := NewColumn("email", TypePGText, false, false, false, 0, false, false, 0, "", []string{}, 9)
+	column2 := NewColumn("name", TypePGText, false, false, false, 0, false, false, 0, "", []string{}, 9)
+	entity := NewEntity(util.PublicSchema, "users", []Column{column1, column2}, nil)
+
+	t.Run("Should add primary key only to indexes", func(t *testing.T) {
+		entity.AddIndex(NewIndex("users_pkey", []string{"userId"}, true, true, true, false, ""))
+		if len(entity.Indexes) != 1 || len(entity.UniqueKeys) != 0 {
+			t.Errorf("Entity.Indexes, Entity.UniqueKeys = %v, %v, want %v, %v", len(entity.Indexes), len(entity.UniqueKeys), 1, 0)
+		}
+	})
+
+	t.Run("Should mark unique column", func(t *testing.T) {
+		entity.AddIndex(NewIndex("users_email_key", []string{"email"}, true, false, true, false, ""))
+		if len(entity.UniqueKeys) != 1 || !entity.Columns[0].IsUnique {
+			t.Errorf("Entity.UniqueKeys, Column.IsUnique = %v, %v, want %v, %v", len(entity.UniqueKeys), entity.Columns[0].IsUnique, 1, true)
+		}
+	})
+
+	t.Run("Should not mark column with partial unique index", func(t *testing.T) {
+		entity.AddIndex(NewIndex("users_name_idx", []string{"name"}, true, false, false, false, `"deletedAt" IS NULL`))
+		if len(entity.UniqueKeys) != 2 || entity.Columns[1].IsUnique {
+			t.Errorf("Entity.UniqueKeys, Column.IsUnique = %v, %v, want %v, %v", len(entity.UniqueKeys), entity.Columns[1].IsUnique, 2, false)
+		}
+	})
+}
+
+func TestEntity_AddCheck(t *testing.T) {
+	column1 := NewColumn("price", TypePGNumeric, false, false, false, 0, false, false, 0, "", []string{}, 9)
+	entity := NewEntity(util.PublicSchema, "products", []Column{column1}, nil)
+
+	t.Run("Should add rule to column", func(t *testing.T) {
+		entity.AddCheck("products_price_check", "CHECK ((price >= (0)::numeric))")
+		if len(entity.Columns[0].Checks) != 1 || len(entity.UnsupportedChecks) != 0 {
+			t.Errorf("Column.Checks, Entity.UnsupportedChecks = %v, %v, want %v, %v", len(entity.Columns[0].Checks), len(entity.UnsupportedChecks), 1, 0)
+		}
+	})
+
+	t.Run("Should record unsupported check", func(t *testing.T) {
+		entity.AddCheck("products_check", "CHECK (((price > 0) OR (discount > 0)))")
+		if len(entity.Columns[0].Checks) != 1 || len(entity.UnsupportedChecks) != 1 {
+			t.Errorf("Column.Checks, Entity.UnsupportedChecks = %v, %v, want %v, %v", len(entity.Columns[0].Checks), len(entity.UnsupportedChecks), 1, 1)
+		}
+	})
+
+	t.Run("Should record check for unknown column", func(t *testing.T) {
+		entity.AddCheck("products_discount_check", "CHECK ((discount >= 0))")
+		if len(entity.UnsupportedChecks) != 2 {
+			t.Errorf("Entity.UnsupportedChecks = %v, want %v", len(entity.UnsupportedChecks), 2)
+		}
+	})
+}
+
+func TestEntity_AddCheck_NotApplied(t *testing.T) {
+	column1 := NewColumn("name", TypePGText, false, false, false, 0, false, false, 0, "", []string{}, 9)
+	entity := NewEntity(util.PublicSchema, "users", []Column{column1}, nil)
+
+	entity.AddCheck("users_name_check", "CHECK ((name > 'a'::text))")
+	if len(entity.Columns[0].Checks) != 0 || len(entity.UnsupportedChecks) != 1 {
+		t.Errorf("Column.Checks, Entity.UnsupportedChecks = %v, %v, want %v, %v", len(entity.Columns[0].Checks), len(entity.UnsupportedChecks), 0, 1)
+	}
+}
+
+func TestEntity_AddDomainCheck(t *testing.T) {
+	column1 := NewColumn("email", TypePGText, false, false, false, 0, false, false, 0, "", []string{}, 9)
+	entity := NewEntity(util.PublicSchema, "users", []Column{column1}, nil)
+
+	t.Run("Should add domain rule to column", func(t *testing.T) {
+		entity.AddDomainCheck("email", "email_check", "CHECK ((length((VALUE)::text) <= 254))")
+		if len(entity.Columns[0].Checks) != 1 || entity.Columns[0].Checks[0].Column != "email" {
+			t.Errorf("Column.Checks = %v, want rule for %v", entity.Columns[0].Checks, "email")
+		}
+	})
+
+	t.Run("Should record domain check with other columns", func(t *testing.T) {
+		entity.AddDomainCheck("email", "email_check", "CHECK ((email <> ''::text))")
+		if len(entity.UnsupportedChecks) != 1 {
+			t.Errorf("Entity.UnsupportedChecks = %v, want %v", len(entity.UnsupportedChecks), 1)
+		}
+	})
+}
+
+func TestEntity_AddColumn_Composite(t *testing.T) {
+	point := NewComposite(util.PublicSchema, "point2d", nil)
+	field := NewColumn("location", "point2d", true, false, false, 0, false, false, 0, "", []string{}, 9)
+	field.AddComposite(&point)
+	address := NewComposite(util.PublicSchema, "address", []Column{field})
+
+	column1 := NewColumn("home", "address", true, false, false, 0, false, false, 0, "", []string{}, 9)
+	column1.AddComposite(&address)
+	column2 := NewColumn("work", "address", true, false, false, 0, false, false, 0, "", []string{}, 9)
+	column2.AddComposite(&address)
+
+	entity := NewEntity(util.PublicSchema, "users", []Column{column1, column2}, nil)
+	if len(entity.Composites) != 2 || entity.Composites[0].PGName != "point2d" {
+		t.Errorf("Entity.Composites = %v, want nested composite first", entity.Composites)
+	}
+}
+
+func TestEntity_AddColumn_Enum(t *testing.T) {
+	mood := NewEnum(util.PublicSchema, "mood", []string{"sad", "happy"})
+	other := NewEnum("hr", "mood", []string{"bored"})
+
+	column1 := NewColumn("mood", TypePGVarchar, false, false, false, 0, false, false, 0, "", []string{}, 9)
+	column1.AddEnum(&mood)
+	column2 := NewColumn("lastMood", TypePGVarchar, true, false, false, 0, false, false, 0, "", []string{}, 9)
+	column2.AddEnum(&mood)
+	column3 := NewColumn("hrMood", TypePGVarchar, true, false, false, 0, false, false, 0, "", []string{}, 9)
+	column3.AddEnum(&other)
+
+	entity := NewEntity(util.PublicSchema, "users", []Column{column1, column2, column3}, nil)
+	if len(entity.Enums) != 2 || entity.Enums[1].PGFullName != "hr.mood" {
+		t.Errorf("Entity.Enums = %v, want enums of both schemas once", entity.Enums)
+	}
+	if column3.EnumType != "hr.mood" || len(column3.Values) != 1 {
+		t.Errorf("Column.EnumType = %v, Column.Values = %v, want %v, %v", column3.EnumType, column3.Values, "hr.mood", other.Values)
+	}
+}
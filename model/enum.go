@@ -0,0 +1,61 @@
+package model
+
+import (
+	"github.com/dizzyfool/genna/util"
+)
+
+// Enum stores information about user-defined enum type
+type Enum struct {
+	GoName     string
+	PGName     string
+	PGSchema   string
+	PGFullName string
+
+	// Values are enum labels in order of declaration
+	Values []string
+}
+
+// EnumEntry stores go constant for one enum value
+type EnumEntry struct {
+	GoName string
+	Value  string
+}
+
+// NewEnum creates Enum from pg info
+func NewEnum(schema, pgName string, values []string) Enum {
+	if values == nil {
+		values = []string{}
+	}
+
+	return Enum{
+		GoName:     util.CamelCased(enumPrefix(schema, pgName)),
+		PGName:     pgName,
+		PGSchema:   schema,
+		PGFullName: util.Join(schema, pgName),
+
+		Values: values,
+	}
+}
+
+// Entries gets constants for all enum values, public schema is omitted in names
+func (e Enum) Entries() []EnumEntry {
+	prefix := enumPrefix(e.PGSchema, e.PGName)
+
+	entries := make([]EnumEntry, len(e.Values))
+	for i, value := range e.Values {
+		entries[i] = EnumEntry{
+			GoName: util.CamelCased(prefix + "_" + util.PackageName(value)),
+			Value:  value,
+		}
+	}
+
+	return entries
+}
+
+func enumPrefix(schema, pgName string) string {
+	if schema == util.PublicSchema {
+		return pgName
+	}
+
+	return schema + "_" + pgName
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dizzyfool/genna/util"
+)
+
+func TestEnum_Entries(t *testing.T) {
+	tests := []struct {
+		name       string
+		schema     string
+		pgName     string
+		values     []string
+		wantGoName string
+		want       []EnumEntry
+	}{
+		{
+			name:       "Should generate from public type",
+			schema:     util.PublicSchema,
+			pgName:     "mood",
+			values:     []string{"sad", "ok", "happy"},
+			wantGoName: "Mood",
+			want: []EnumEntry{
+				{GoName: "MoodSad", Value: "sad"},
+				{GoName: "MoodOk", Value: "ok"},
+				{GoName: "MoodHappy", Value: "happy"},
+			},
+		},
+		{
+			name:       "Should generate from custom schema",
+			schema:     "billing",
+			pgName:     "order_status",
+			values:     []string{"new", "Paid-Out"},
+			wantGoName: "BillingOrderStatus",
+			want: []EnumEntry{
+				{GoName: "BillingOrderStatusNew", Value: "new"},
+				{GoName: "BillingOrderStatusPaidOut", Value: "Paid-Out"},
+			},
+		},
+		{
+			name:       "Should generate from empty enum",
+			schema:     util.PublicSchema,
+			pgName:     "nothing",
+			wantGoName: "Nothing",
+			want:       []EnumEntry{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEnum(tt.schema, tt.pgName, tt.values)
+			if e.GoName != tt.wantGoName {
+				t.Errorf("NewEnum().GoName = %v, want %v", e.GoName, tt.wantGoName)
+			}
+			if got := e.Entries(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Enum.Entries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
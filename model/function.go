@@ -0,0 +1,116 @@
+package model
+
+import (
+	"github.com/dizzyfool/genna/util"
+)
+
+const (
+	// TypePGVoid is a return type of functions returning nothing
+	TypePGVoid = "void"
+	// TypePGRecord is a return type of functions with out parameters
+	TypePGRecord = "record"
+)
+
+// Function stores information about stored function or procedure
+type Function struct {
+	GoName     string
+	PGName     string
+	PGSchema   string
+	PGFullName string
+
+	// Description is a function comment from database
+	Description string
+
+	// Procedure is set for procedures, they are invoked by call and return nothing
+	Procedure bool
+	// ReturnType is a declared pg return type, ReturnsSet is set for setof and table functions
+	ReturnType string
+	ReturnsSet bool
+
+	// Args are input arguments in order of declaration
+	Args []Argument
+	// Result are output columns, result of single column is returned as scalar
+	Result []Column
+
+	Imports []string
+}
+
+// Argument stores information about input argument of function
+type Argument struct {
+	Column
+
+	// HasDefault is set if argument could be omitted
+	HasDefault bool
+	// Variadic is set for variadic argument, its type is an array
+	Variadic bool
+}
+
+// NewFunction creates Function from pg info
+func NewFunction(schema, pgName, returnType string, returnsSet, procedure bool) Function {
+	return Function{
+		GoName:     util.CamelCased(schema) + util.CamelCased(util.Sanitize(pgName)),
+		PGName:     pgName,
+		PGSchema:   schema,
+		PGFullName: util.Join(schema, pgName),
+
+		Procedure:  procedure,
+		ReturnType: returnType,
+		ReturnsSet: returnsSet,
+
+		Args:    []Argument{},
+		Result:  []Column{},
+		Imports: []string{},
+	}
+}
+
+// AddArgument adds input argument to function
+func (f *Function) AddArgument(arg Argument) {
+	f.Args = append(f.Args, arg)
+	f.addImport(arg.Import)
+}
+
+// AddResult adds output column to function
+func (f *Function) AddResult(column Column) {
+	f.Result = append(f.Result, column)
+	f.addImport(column.Import)
+}
+
+func (f *Function) addImport(imp string) {
+	if imp == "" {
+		return
+	}
+
+	for _, i := range f.Imports {
+		if i == imp {
+			return
+		}
+	}
+	f.Imports = append(f.Imports, imp)
+}
+
+// ReturnsVoid checks if function returns nothing
+func (f Function) ReturnsVoid() bool {
+	return len(f.Result) == 0
+}
+
+// IsSupported checks if all arguments and results have go types
+// record can not be read without out parameters
+func (f Function) IsSupported() bool {
+	if f.ReturnType == TypePGRecord && f.ReturnsVoid() {
+		return false
+	}
+
+	for _, arg := range f.Args {
+		if arg.GoType == TypeInterface {
+			return false
+		}
+	}
+
+	for _, column := range f.Result {
+		if column.GoType == TypeInterface || column.Composite != nil {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestNewFunction(t *testing.T) {
+	tests := []struct {
+		name       string
+		schema     string
+		pgName     string
+		wantGoName string
+	}{
+		{
+			name:       "Should generate from public function",
+			schema:     "public",
+			pgName:     "get_user_stats",
+			wantGoName: "PublicGetUserStats",
+		},
+		{
+			name:       "Should generate from custom schema",
+			schema:     "billing",
+			pgName:     "close-period",
+			wantGoName: "BillingClosePeriod",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewFunction(tt.schema, tt.pgName, TypePGVoid, false, false); got.GoName != tt.wantGoName {
+				t.Errorf("NewFunction().GoName = %v, want %v", got.GoName, tt.wantGoName)
+			}
+		})
+	}
+}
+
+func TestFunction_IsSupported(t *testing.T) {
+	id := NewColumn("id", TypePGInt4, false, false, false, 0, false, false, 0, "", nil, 9)
+	poly := NewColumn("value", "anyelement", false, false, false, 0, false, false, 0, "", nil, 9)
+
+	tests := []struct {
+		name     string
+		function func() Function
+		want     bool
+	}{
+		{
+			name: "Should support function with typed arguments and result",
+			function: func() Function {
+				f := NewFunction("public", "next_id", TypePGInt4, false, false)
+				f.AddArgument(Argument{Column: id})
+				f.AddResult(id)
+				return f
+			},
+			want: true,
+		},
+		{
+			name: "Should support void function",
+			function: func() Function {
+				return NewFunction("public", "refresh", TypePGVoid, false, false)
+			},
+			want: true,
+		},
+		{
+			name: "Should not support record without out parameters",
+			function: func() Function {
+				return NewFunction("public", "rows", TypePGRecord, true, false)
+			},
+			want: false,
+		},
+		{
+			name: "Should not support polymorphic argument",
+			function: func() Function {
+				f := NewFunction("public", "first", TypePGVoid, false, false)
+				f.AddArgument(Argument{Column: poly})
+				return f
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.function().IsSupported(); got != tt.want {
+				t.Errorf("Function.IsSupported() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package model
+
+// Index stores information about table index or unique constraint
+type Index struct {
+	Name string
+
+	// Columns are column names, expression index keys are stored as expressions
+	Columns []string
+
+	IsUnique     bool
+	IsPrimary    bool
+	IsConstraint bool
+
+	// HasExpressions is set if any of Columns is an expression
+	HasExpressions bool
+	// Predicate is a where clause of partial index
+	Predicate string
+}
+
+// NewIndex creates Index from pg info
+func NewIndex(name string, columns []string, unique, primary, constraint, expressions bool, predicate string) Index {
+	return Index{
+		Name:    name,
+		Columns: columns,
+
+		IsUnique:     unique,
+		IsPrimary:    primary,
+		IsConstraint: constraint,
+
+		HasExpressions: expressions,
+		Predicate:      predicate,
+	}
+}
+
+// IsPartial checks if index has predicate
+func (i Index) IsPartial() bool {
+	return i.Predicate != ""
+}
+
+// IsSimple checks if index is built on plain columns for all rows
+func (i Index) IsSimple() bool {
+	return !i.HasExpressions && !i.IsPartial()
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestIndex_IsSimple(t *testing.T) {
+	tests := []struct {
+		name  string
+		index Index
+		want  bool
+	}{
+		{
+			name:  "Should be simple for plain columns",
+			index: NewIndex("users_email_key", []string{"email"}, true, false, true, false, ""),
+			want:  true,
+		},
+		{
+			name:  "Should not be simple for partial index",
+			index: NewIndex("users_email_idx", []string{"email"}, true, false, false, false, "deleted_at IS NULL"),
+			want:  false,
+		},
+		{
+			name:  "Should not be simple for expression index",
+			index: NewIndex("users_lower_email_idx", []string{"lower(email)"}, true, false, false, true, ""),
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.index.IsSimple(); got != tt.want {
+				t.Errorf("Index.IsSimple() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
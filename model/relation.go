@@ -10,7 +10,9 @@ import (
 // Relation stores relation
 type Relation struct {
 	FKFields []string
-	GoName   string
+	// RefFields are columns referenced by FKFields, in the same order
+	RefFields []string
+	GoName    string
 
 	TargetPGName     string
 	TargetPGSchema   string
@@ -19,34 +21,94 @@ type Relation struct {
 	TargetEntity *Entity
 
 	GoType string
+
+	// Inverse relation has FKFields in target table
+	Inverse bool
+	// HasMany is set for inverse relation without unique constraint on FKFields
+	HasMany bool
+	// RefPK is set if RefFields are primary key, go-pg joins relations by primary keys only
+	RefPK bool
+
+	// Many2Many is a junction table full name, FKFields and JoinFKFields are its columns
+	Many2Many    string
+	JoinFKFields []string
 }
 
 // NewRelation creates relation from pg info
-func NewRelation(sourceColumns []string, targetSchema, targetTable string) Relation {
+func NewRelation(sourceColumns []string, targetSchema, targetTable string, targetColumns []string) Relation {
 	names := make([]string, len(sourceColumns))
 	for i, name := range sourceColumns {
 		names[i] = util.ReplaceSuffix(util.ColumnName(name), util.ID, "")
 	}
 
-	numRegEx := regexp.MustCompile(`[0-9]`)
+	return Relation{
+		FKFields:  sourceColumns,
+		RefFields: targetColumns,
+		GoName:    strings.Join(names, ""),
+
+		TargetPGName:     targetTable,
+		TargetPGSchema:   targetSchema,
+		TargetPGFullName: util.JoinF(util.SchemaNameInFull(targetSchema), targetTable),
+		// TargetPGFullName: util.JoinF(targetSchema, targetTable),
 
-	typ := util.EntityName(targetTable)
-	typ = util.CamelCased(targetSchema) + typ
-	typ = numRegEx.ReplaceAllString(typ, "")
+		GoType: relationType(targetSchema, targetTable),
+	}
+}
+
+// NewInverseRelation creates relation from referenced table to the table with FK
+// unique means that FK columns are unique and relation is has-one, has-many otherwise
+func NewInverseRelation(sourceColumns []string, sourceSchema, sourceTable string, targetColumns []string, unique bool) Relation {
+	goName := util.EntityName(sourceTable)
+	if !unique {
+		goName = util.CamelCased(util.Sanitize(sourceTable))
+	}
 
 	return Relation{
-		FKFields: sourceColumns,
-		GoName:   strings.Join(names, ""),
+		FKFields:  sourceColumns,
+		RefFields: targetColumns,
+		GoName:    goName,
+
+		TargetPGName:     sourceTable,
+		TargetPGSchema:   sourceSchema,
+		TargetPGFullName: util.JoinF(util.SchemaNameInFull(sourceSchema), sourceTable),
+
+		GoType: relationType(sourceSchema, sourceTable),
+
+		Inverse: true,
+		HasMany: !unique,
+	}
+}
+
+// NewMany2ManyRelation creates relation to target table through junction table
+// fkColumns references source table, joinFKColumns references target table
+func NewMany2ManyRelation(junctionSchema, junctionTable string, fkColumns, joinFKColumns []string, targetSchema, targetTable string) Relation {
+	return Relation{
+		FKFields:     fkColumns,
+		JoinFKFields: joinFKColumns,
+		GoName:       util.CamelCased(util.Sanitize(targetTable)),
 
 		TargetPGName:     targetTable,
 		TargetPGSchema:   targetSchema,
 		TargetPGFullName: util.JoinF(util.SchemaNameInFull(targetSchema), targetTable),
-		// TargetPGFullName: util.JoinF(targetSchema, targetTable),
 
-		GoType: typ,
+		GoType: relationType(targetSchema, targetTable),
+
+		Inverse:   true,
+		HasMany:   true,
+		Many2Many: util.JoinF(util.SchemaNameInFull(junctionSchema), junctionTable),
 	}
 }
 
+// relationType gets go type of a related entity, same as Entity.GoName
+func relationType(schema, table string) string {
+	numRegEx := regexp.MustCompile(`[0-9]`)
+
+	typ := util.EntityName(table)
+	typ = util.CamelCased(schema) + typ
+
+	return numRegEx.ReplaceAllString(typ, "")
+}
+
 func (r *Relation) AddEntity(entity *Entity) {
 	r.TargetEntity = entity
 }
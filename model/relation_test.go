@@ -38,7 +38,7 @@ func TestRelation_GoName(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := NewRelation(tt.fields.SourceColumns, tt.fields.TargetSchema, tt.fields.TargetTable)
+			r := NewRelation(tt.fields.SourceColumns, tt.fields.TargetSchema, tt.fields.TargetTable, nil)
 			if got := r.GoName; got != tt.want {
 				t.Errorf("Relation.GoName = %v, want %v", got, tt.want)
 			}
@@ -139,10 +139,58 @@ func TestRelation_GoType(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := NewRelation([]string{"ID"}, tt.fields.TargetSchema, tt.fields.TargetTable)
+			r := NewRelation([]string{"ID"}, tt.fields.TargetSchema, tt.fields.TargetTable, []string{"ID"})
 			if got := r.GoType; got != tt.want {
 				t.Errorf("Relation.GoType = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestNewInverseRelation(t *testing.T) {
+	type fields struct {
+		SourceColumns []string
+		SourceSchema  string
+		SourceTable   string
+		Unique        bool
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		wantName    string
+		wantHasMany bool
+	}{
+		{
+			name: "Should generate has-many relation",
+			fields: fields{
+				SourceColumns: []string{"countryId"},
+				SourceSchema:  util.PublicSchema,
+				SourceTable:   "users",
+			},
+			wantName:    "Users",
+			wantHasMany: true,
+		},
+		{
+			name: "Should generate has-one relation",
+			fields: fields{
+				SourceColumns: []string{"userId"},
+				SourceSchema:  util.PublicSchema,
+				SourceTable:   "user_profiles",
+				Unique:        true,
+			},
+			wantName:    "UserProfile",
+			wantHasMany: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewInverseRelation(tt.fields.SourceColumns, tt.fields.SourceSchema, tt.fields.SourceTable, []string{"id"}, tt.fields.Unique)
+			if r.GoName != tt.wantName {
+				t.Errorf("Relation.GoName = %v, want %v", r.GoName, tt.wantName)
+			}
+			if !r.Inverse || r.HasMany != tt.wantHasMany {
+				t.Errorf("Relation.Inverse, Relation.HasMany = %v, %v, want %v, %v", r.Inverse, r.HasMany, true, tt.wantHasMany)
+			}
+		})
+	}
+}
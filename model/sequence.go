@@ -0,0 +1,64 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/dizzyfool/genna/util"
+)
+
+// sequenceSuffix is a suffix of sequences created for serial columns
+const sequenceSuffix = "_seq"
+
+// Sequence stores information about sequence
+type Sequence struct {
+	// GoName is a name without _seq and id suffixes, e.g. PublicUsers for public.users_id_seq
+	GoName     string
+	PGName     string
+	PGSchema   string
+	PGFullName string
+
+	// Description is a sequence comment from database
+	Description string
+
+	// PGType is one of int2, int4 and int8, GoType is a type of its values
+	PGType string
+	GoType string
+
+	// OwnerSchema, OwnerTable and OwnerColumn are set for sequences owned by column, e.g. serial
+	OwnerSchema string
+	OwnerTable  string
+	OwnerColumn string
+}
+
+// NewSequence creates Sequence from pg info
+func NewSequence(schema, pgName, pgType string) Sequence {
+	name := util.CamelCased(util.Sanitize(strings.TrimSuffix(pgName, sequenceSuffix)))
+	if trimmed := strings.TrimSuffix(name, util.Id); trimmed != "" {
+		name = trimmed
+	}
+
+	goType, err := GoType(pgType)
+	if err != nil {
+		goType = TypeInt64
+	}
+
+	return Sequence{
+		GoName:     util.CamelCased(schema) + name,
+		PGName:     pgName,
+		PGSchema:   schema,
+		PGFullName: util.Join(schema, pgName),
+
+		PGType: pgType,
+		GoType: goType,
+	}
+}
+
+// AddOwner sets column owning sequence
+func (s *Sequence) AddOwner(schema, table, column string) {
+	s.OwnerSchema, s.OwnerTable, s.OwnerColumn = schema, table, column
+}
+
+// IsOwned checks if sequence is owned by column
+func (s Sequence) IsOwned() bool {
+	return s.OwnerColumn != ""
+}
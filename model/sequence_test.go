@@ -0,0 +1,52 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestNewSequence(t *testing.T) {
+	tests := []struct {
+		name       string
+		schema     string
+		pgName     string
+		pgType     string
+		wantGoName string
+		wantGoType string
+	}{
+		{
+			name:       "Should trim suffixes of serial sequence",
+			schema:     "public",
+			pgName:     "users_id_seq",
+			pgType:     TypePGInt4,
+			wantGoName: "PublicUsers",
+			wantGoType: TypeInt,
+		},
+		{
+			name:       "Should generate from standalone sequence",
+			schema:     "billing",
+			pgName:     "invoice_number",
+			pgType:     TypePGInt8,
+			wantGoName: "BillingInvoiceNumber",
+			wantGoType: TypeInt64,
+		},
+		{
+			name:       "Should keep id sequence",
+			schema:     "public",
+			pgName:     "id_seq",
+			pgType:     TypePGInt8,
+			wantGoName: "PublicId",
+			wantGoType: TypeInt64,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewSequence(tt.schema, tt.pgName, tt.pgType)
+			if got.GoName != tt.wantGoName {
+				t.Errorf("NewSequence().GoName = %v, want %v", got.GoName, tt.wantGoName)
+			}
+			if got.GoType != tt.wantGoType {
+				t.Errorf("NewSequence().GoType = %v, want %v", got.GoType, tt.wantGoType)
+			}
+		})
+	}
+}
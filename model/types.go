@@ -53,6 +53,38 @@ const (
 	TypePGCidr = "cidr"
 	// TypePGPoint is a postgres type
 	TypePGPoint = "point"
+	// TypePGInt4range is a postgres type
+	TypePGInt4range = "int4range"
+	// TypePGInt8range is a postgres type
+	TypePGInt8range = "int8range"
+	// TypePGNumrange is a postgres type
+	TypePGNumrange = "numrange"
+	// TypePGTsrange is a postgres type
+	TypePGTsrange = "tsrange"
+	// TypePGTstzrange is a postgres type
+	TypePGTstzrange = "tstzrange"
+	// TypePGDaterange is a postgres type
+	TypePGDaterange = "daterange"
+	// TypePGInt4multirange is a postgres type
+	TypePGInt4multirange = "int4multirange"
+	// TypePGInt8multirange is a postgres type
+	TypePGInt8multirange = "int8multirange"
+	// TypePGNummultirange is a postgres type
+	TypePGNummultirange = "nummultirange"
+	// TypePGTsmultirange is a postgres type
+	TypePGTsmultirange = "tsmultirange"
+	// TypePGTstzmultirange is a postgres type
+	TypePGTstzmultirange = "tstzmultirange"
+	// TypePGDatemultirange is a postgres type
+	TypePGDatemultirange = "datemultirange"
+	// TypePGCitext is a postgres type from citext extension
+	TypePGCitext = "citext"
+	// TypePGLtree is a postgres type from ltree extension
+	TypePGLtree = "ltree"
+	// TypePGGeometry is a postgres type from postgis extension
+	TypePGGeometry = "geometry"
+	// TypePGGeography is a postgres type from postgis extension
+	TypePGGeography = "geography"
 
 	// TypeInt is a go type
 	TypeInt = "int"
@@ -82,9 +114,38 @@ const (
 	TypeIP = "net.IP"
 	// TypeIPNet is a go type
 	TypeIPNet = "net.IPNet"
+	// TypeDecimal is a go type used for numeric if decimal mapping is on
+	TypeDecimal = "decimal.Decimal"
+	// TypeNullDecimal is a go type used for nullable numeric with sql nulls
+	TypeNullDecimal = "decimal.NullDecimal"
+	// TypeIntRange is a go type
+	TypeIntRange = "pgrange.IntRange"
+	// TypeNumRange is a go type
+	TypeNumRange = "pgrange.NumRange"
+	// TypeTimeRange is a go type
+	TypeTimeRange = "pgrange.TimeRange"
+	// TypeIntMultirange is a go type
+	TypeIntMultirange = "pgrange.IntMultirange"
+	// TypeNumMultirange is a go type
+	TypeNumMultirange = "pgrange.NumMultirange"
+	// TypeTimeMultirange is a go type
+	TypeTimeMultirange = "pgrange.TimeMultirange"
+	// TypeLtree is a go type
+	TypeLtree = "pgext.Ltree"
+	// TypeGeometry is a go type
+	TypeGeometry = "pgext.Geometry"
 
 	// TypeInterface is a go type
 	TypeInterface = "interface{}"
+
+	// DecimalImport is an import path of decimal types
+	DecimalImport = "github.com/shopspring/decimal"
+	// RangeImport is an import path of range types
+	RangeImport = "github.com/dizzyfool/genna/pgrange"
+	// ExtImport is an import path of extension types
+	ExtImport = "github.com/dizzyfool/genna/pgext"
+	// CompositeImport is an import path of helpers for arrays of composite types
+	CompositeImport = "github.com/dizzyfool/genna/pgcomposite"
 )
 
 // GoType generates simple go type from pg type
@@ -98,7 +159,7 @@ func GoType(pgType string) (string, error) {
 		return TypeFloat32, nil
 	case TypePGNumeric, TypePGFloat8:
 		return TypeFloat64, nil
-	case TypePGText, TypePGVarchar, TypePGUuid, TypePGBpchar, TypePGPoint:
+	case TypePGText, TypePGVarchar, TypePGUuid, TypePGBpchar, TypePGPoint, TypePGCitext:
 		return TypeString, nil
 	case TypePGBytea:
 		return TypeByteSlice, nil
@@ -116,20 +177,51 @@ func GoType(pgType string) (string, error) {
 		return TypeIP, nil
 	case TypePGCidr:
 		return TypeIPNet, nil
+	case TypePGInt4range, TypePGInt8range:
+		return TypeIntRange, nil
+	case TypePGNumrange:
+		return TypeNumRange, nil
+	case TypePGTsrange, TypePGTstzrange, TypePGDaterange:
+		return TypeTimeRange, nil
+	case TypePGInt4multirange, TypePGInt8multirange:
+		return TypeIntMultirange, nil
+	case TypePGNummultirange:
+		return TypeNumMultirange, nil
+	case TypePGTsmultirange, TypePGTstzmultirange, TypePGDatemultirange:
+		return TypeTimeMultirange, nil
+	case TypePGLtree:
+		return TypeLtree, nil
+	case TypePGGeometry, TypePGGeography:
+		return TypeGeometry, nil
 	}
 
 	return "", fmt.Errorf("unsupported type: %s", pgType)
 }
 
+// GoExactType generates simple go type from pg type
+// numeric is mapped to exact decimal type if decimal set
+func GoExactType(pgType string, decimal bool) (string, error) {
+	if decimal && pgType == TypePGNumeric {
+		return TypeDecimal, nil
+	}
+
+	return GoType(pgType)
+}
+
 // GoSlice generates go slice type from pg array
-func GoSlice(pgType string, dimensions int) (string, error) {
+func GoSlice(pgType string, dimensions int, decimal bool) (string, error) {
 	switch pgType {
 	case TypePGTimestamp, TypePGTimestamptz, TypePGDate, TypePGTime, TypePGTimetz,
-		TypePGInterval, TypePGHstore, TypePGInet, TypePGCidr:
+		TypePGInterval, TypePGHstore, TypePGInet, TypePGCidr,
+		TypePGLtree, TypePGGeometry, TypePGGeography:
 		return "", fmt.Errorf("unsupported array type: %s", pgType)
 	}
 
-	typ, err := GoType(pgType)
+	if IsRange(pgType) {
+		return "", fmt.Errorf("unsupported array type: %s", pgType)
+	}
+
+	typ, err := GoExactType(pgType, decimal)
 	if err != nil {
 		return "", err
 	}
@@ -142,7 +234,15 @@ func GoSlice(pgType string, dimensions int) (string, error) {
 }
 
 // GoNullable generates all go types from pg type with pointer
-func GoNullable(pgType string, useSQLNull bool) (string, error) {
+func GoNullable(pgType string, useSQLNull, decimal bool) (string, error) {
+	if decimal && pgType == TypePGNumeric {
+		// decimal can not be scanned from null, so pointer is used
+		if useSQLNull {
+			return TypeNullDecimal, nil
+		}
+		return "*" + TypeDecimal, nil
+	}
+
 	// avoiding pointers with sql.Null... types
 	if useSQLNull {
 		switch pgType {
@@ -152,7 +252,7 @@ func GoNullable(pgType string, useSQLNull bool) (string, error) {
 			return "sql.NullFloat64", nil
 		case TypePGBool:
 			return "sql.NullBool", nil
-		case TypePGText, TypePGVarchar, TypePGUuid, TypePGBpchar, TypePGPoint:
+		case TypePGText, TypePGVarchar, TypePGUuid, TypePGBpchar, TypePGPoint, TypePGCitext:
 			return "sql.NullString", nil
 		case TypePGTimestamp, TypePGTimestamptz, TypePGDate, TypePGTime, TypePGTimetz:
 			return "pg.NullTime", nil
@@ -174,13 +274,17 @@ func GoNullable(pgType string, useSQLNull bool) (string, error) {
 }
 
 // GoImport generates import from go type
-func GoImport(pgType string, nullable, useSQLNull bool, ver int) string {
+func GoImport(pgType string, nullable, useSQLNull, decimal bool, ver int) string {
+	if decimal && pgType == TypePGNumeric {
+		return DecimalImport
+	}
+
 	if nullable && useSQLNull {
 		switch pgType {
 		case TypePGInt2, TypePGInt4, TypePGInt8,
 			TypePGNumeric, TypePGFloat4, TypePGFloat8,
 			TypePGBool,
-			TypePGText, TypePGVarchar, TypePGUuid, TypePGBpchar, TypePGPoint:
+			TypePGText, TypePGVarchar, TypePGUuid, TypePGBpchar, TypePGPoint, TypePGCitext:
 			return "database/sql"
 		case TypePGTimestamp, TypePGTimestamptz, TypePGDate, TypePGTime, TypePGTimetz:
 			if ver == 9 {
@@ -191,7 +295,13 @@ func GoImport(pgType string, nullable, useSQLNull bool, ver int) string {
 		}
 	}
 
+	if IsRange(pgType) {
+		return RangeImport
+	}
+
 	switch pgType {
+	case TypePGLtree, TypePGGeometry, TypePGGeography:
+		return ExtImport
 	case TypePGInet, TypePGCidr:
 		return "net"
 	case TypePGTimestamp, TypePGTimestamptz, TypePGDate, TypePGTime, TypePGTimetz, TypePGInterval:
@@ -200,3 +310,49 @@ func GoImport(pgType string, nullable, useSQLNull bool, ver int) string {
 
 	return ""
 }
+
+// IsRange checks if pg type is range or multirange
+func IsRange(pgType string) bool {
+	_, ok := rangeElements[pgType]
+	return ok
+}
+
+// rangeElements are element types of ranges and range types of multiranges
+var rangeElements = map[string][2]string{
+	TypePGInt4range: {TypePGInt4, TypePGInt4range},
+	TypePGInt8range: {TypePGInt8, TypePGInt8range},
+	TypePGNumrange:  {TypePGNumeric, TypePGNumrange},
+	TypePGTsrange:   {TypePGTimestamp, TypePGTsrange},
+	TypePGTstzrange: {TypePGTimestamptz, TypePGTstzrange},
+	TypePGDaterange: {TypePGDate, TypePGDaterange},
+
+	TypePGInt4multirange: {TypePGInt4, TypePGInt4range},
+	TypePGInt8multirange: {TypePGInt8, TypePGInt8range},
+	TypePGNummultirange:  {TypePGNumeric, TypePGNumrange},
+	TypePGTsmultirange:   {TypePGTimestamp, TypePGTsrange},
+	TypePGTstzmultirange: {TypePGTimestamptz, TypePGTstzrange},
+	TypePGDatemultirange: {TypePGDate, TypePGDaterange},
+}
+
+// RangeElement gets element type of range or multirange, e.g. timestamptz for tstzrange
+func RangeElement(pgType string) string {
+	return rangeElements[pgType][0]
+}
+
+// RangeOf gets range type of multirange, range itself is returned for ranges
+func RangeOf(pgType string) string {
+	return rangeElements[pgType][1]
+}
+
+// extensions are extensions providing types
+var extensions = map[string]string{
+	TypePGCitext:    "citext",
+	TypePGLtree:     "ltree",
+	TypePGGeometry:  "postgis",
+	TypePGGeography: "postgis",
+}
+
+// Extension gets name of extension providing pg type, empty for built-in types
+func Extension(pgType string) string {
+	return extensions[pgType]
+}
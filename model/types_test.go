@@ -82,6 +82,36 @@ func Test_goType(t *testing.T) {
 			pgTypes: []string{TypePGCidr},
 			want:    TypeIPNet,
 		},
+		{
+			name:    "Should get int range",
+			pgTypes: []string{TypePGInt4range, TypePGInt8range},
+			want:    TypeIntRange,
+		},
+		{
+			name:    "Should get time range",
+			pgTypes: []string{TypePGTsrange, TypePGTstzrange, TypePGDaterange},
+			want:    TypeTimeRange,
+		},
+		{
+			name:    "Should get string from citext",
+			pgTypes: []string{TypePGCitext},
+			want:    TypeString,
+		},
+		{
+			name:    "Should get ltree",
+			pgTypes: []string{TypePGLtree},
+			want:    TypeLtree,
+		},
+		{
+			name:    "Should get geometry",
+			pgTypes: []string{TypePGGeometry, TypePGGeography},
+			want:    TypeGeometry,
+		},
+		{
+			name:    "Should get num multirange",
+			pgTypes: []string{TypePGNummultirange},
+			want:    TypeNumMultirange,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -99,6 +129,45 @@ func Test_goType(t *testing.T) {
 	}
 }
 
+func Test_goExactType(t *testing.T) {
+	tests := []struct {
+		name    string
+		pgType  string
+		decimal bool
+		want    string
+	}{
+		{
+			name:   "Should get float64 for numeric by default",
+			pgType: TypePGNumeric,
+			want:   TypeFloat64,
+		},
+		{
+			name:    "Should get decimal for numeric",
+			pgType:  TypePGNumeric,
+			decimal: true,
+			want:    TypeDecimal,
+		},
+		{
+			name:    "Should keep float64 for float8",
+			pgType:  TypePGFloat8,
+			decimal: true,
+			want:    TypeFloat64,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GoExactType(tt.pgType, tt.decimal)
+			if err != nil {
+				t.Errorf("GoExactType() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GoExactType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_goSlice(t *testing.T) {
 	type args struct {
 		pgType     string
@@ -107,6 +176,7 @@ func Test_goSlice(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
+		decimal bool
 		want    string
 		wantErr bool
 	}{
@@ -135,6 +205,18 @@ func Test_goSlice(t *testing.T) {
 			args: args{TypePGNumeric, 1},
 			want: "[]float64",
 		},
+		{
+			name:    "Should generate decimal array",
+			args:    args{TypePGNumeric, 2},
+			decimal: true,
+			want:    "[][]decimal.Decimal",
+		},
+		{
+			name:    "Should ignore decimal for float8 array",
+			args:    args{TypePGFloat8, 1},
+			decimal: true,
+			want:    "[]float64",
+		},
 		{
 			name: "Should generate float4 array",
 			args: args{TypePGFloat4, 1},
@@ -195,10 +277,25 @@ func Test_goSlice(t *testing.T) {
 			args:    args{"unknown", 1},
 			wantErr: true,
 		},
+		{
+			name: "Should generate citext array",
+			args: args{TypePGCitext, 1},
+			want: "[]string",
+		},
+		{
+			name:    "Should not generate geometry array",
+			args:    args{TypePGGeometry, 1},
+			wantErr: true,
+		},
+		{
+			name:    "Should not generate range array",
+			args:    args{TypePGTstzrange, 1},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := GoSlice(tt.args.pgType, tt.args.dimensions)
+			got, err := GoSlice(tt.args.pgType, tt.args.dimensions, tt.decimal)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GoSlice() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -215,6 +312,7 @@ func Test_goNullable(t *testing.T) {
 		name          string
 		pgType        string
 		avoidPointers bool
+		decimal       bool
 		want          string
 		wantErr       bool
 	}{
@@ -331,6 +429,12 @@ func Test_goNullable(t *testing.T) {
 			avoidPointers: true,
 			want:          "sql.NullString",
 		},
+		{
+			name:          "Should generate citext type avoiding pointers to sql.NullString",
+			pgType:        TypePGCitext,
+			avoidPointers: true,
+			want:          "sql.NullString",
+		},
 		{
 			name:          "Should generate bool type avoiding pointers to sql.NullBool",
 			pgType:        TypePGBool,
@@ -343,10 +447,23 @@ func Test_goNullable(t *testing.T) {
 			avoidPointers: true,
 			want:          "sql.NullFloat64",
 		},
+		{
+			name:    "Should generate decimal type with pointer",
+			pgType:  TypePGNumeric,
+			decimal: true,
+			want:    "*decimal.Decimal",
+		},
+		{
+			name:          "Should generate decimal type avoiding pointers to decimal.NullDecimal",
+			pgType:        TypePGNumeric,
+			avoidPointers: true,
+			decimal:       true,
+			want:          "decimal.NullDecimal",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := GoNullable(tt.pgType, tt.avoidPointers)
+			got, err := GoNullable(tt.pgType, tt.avoidPointers, tt.decimal)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GoNullable() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -363,6 +480,7 @@ func Test_goImport(t *testing.T) {
 		pgTypes       []string
 		nullable      bool
 		avoidPointers bool
+		decimal       bool
 		ver           int
 	}
 	tests := []struct {
@@ -475,14 +593,75 @@ func Test_goImport(t *testing.T) {
 			},
 			want: "github.com/go-pg/pg/v9",
 		},
+		{
+			name: "Should generate decimal import for numeric",
+			args: args{
+				pgTypes: []string{TypePGNumeric},
+				ver:     9,
+				decimal: true,
+			},
+			want: DecimalImport,
+		},
+		{
+			name: "Should generate decimal import for nullable numeric",
+			args: args{
+				pgTypes:       []string{TypePGNumeric},
+				ver:           9,
+				nullable:      true,
+				avoidPointers: true,
+				decimal:       true,
+			},
+			want: DecimalImport,
+		},
+		{
+			name: "Should generate range import",
+			args: args{
+				pgTypes:  []string{TypePGInt4range, TypePGTstzrange, TypePGDatemultirange},
+				ver:      9,
+				nullable: true,
+			},
+			want: RangeImport,
+		},
+		{
+			name: "Should generate extension import",
+			args: args{
+				pgTypes:  []string{TypePGLtree, TypePGGeometry, TypePGGeography},
+				ver:      9,
+				nullable: true,
+			},
+			want: ExtImport,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			for _, pgType := range tt.args.pgTypes {
-				if got := GoImport(pgType, tt.args.nullable, tt.args.avoidPointers, tt.args.ver); got != tt.want {
+				if got := GoImport(pgType, tt.args.nullable, tt.args.avoidPointers, tt.args.decimal, tt.args.ver); got != tt.want {
 					t.Errorf("GoImport() = %v, want %v", got, tt.want)
 				}
 			}
 		})
 	}
 }
+
+func TestRangeElement(t *testing.T) {
+	tests := []struct {
+		pgType      string
+		wantElement string
+		wantRange   string
+	}{
+		{TypePGTstzrange, TypePGTimestamptz, TypePGTstzrange},
+		{TypePGDatemultirange, TypePGDate, TypePGDaterange},
+		{TypePGNumrange, TypePGNumeric, TypePGNumrange},
+		{TypePGText, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pgType, func(t *testing.T) {
+			if got := RangeElement(tt.pgType); got != tt.wantElement {
+				t.Errorf("RangeElement() = %v, want %v", got, tt.wantElement)
+			}
+			if got := RangeOf(tt.pgType); got != tt.wantRange {
+				t.Errorf("RangeOf() = %v, want %v", got, tt.wantRange)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Package pgcomposite scans and appends elements of arrays of composite types for go-pg v9
+//
+// go-pg supports composite columns tagged with composite option only,
+// generated composite structs use Scan and Append to be elements of arrays
+package pgcomposite
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/go-pg/pg/v9/orm"
+	"github.com/go-pg/pg/v9/types"
+)
+
+var (
+	appenderType = reflect.TypeOf((*types.ValueAppender)(nil)).Elem()
+
+	// wrappers are structs with composite field indexed by type of composite
+	wrappers sync.Map
+)
+
+// wrapper is a struct with one field of composite type tagged with composite option
+type wrapper struct {
+	typ   reflect.Type
+	field *orm.Field
+}
+
+// Scan scans composite value in text format to struct pointed by v, NULL is scanned as zero value
+func Scan(v interface{}, rd types.Reader, n int) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("pgcomposite: Scan(non-pointer to struct %T)", v)
+	}
+	value = value.Elem()
+
+	// go-pg scans composites only to tagged fields, so value is scanned to field of wrapper struct
+	w := newWrapper(value.Type())
+	strct := reflect.New(w.typ).Elem()
+	if err := w.field.ScanValue(strct, rd, n); err != nil {
+		return err
+	}
+
+	value.Set(strct.Field(0))
+	return nil
+}
+
+// Append appends composite value of struct v in text format quoted according to flags
+func Append(b []byte, v interface{}, flags int) []byte {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Struct {
+		return types.AppendNull(b, flags)
+	}
+
+	return types.AppendString(b, string(text(value)), flags)
+}
+
+// text gets composite value in text format, e.g. ("1","a ""b""",), every field is quoted, NULL is empty
+func text(value reflect.Value) []byte {
+	b := []byte{'('}
+	for i, field := range orm.GetTable(value.Type()).Fields {
+		if i > 0 {
+			b = append(b, ',')
+		}
+
+		elem, ok := fieldText(field, value)
+		if !ok {
+			continue
+		}
+
+		b = append(b, '"')
+		for _, c := range elem {
+			// quotes and backslashes are doubled, like in output of postgres
+			if c == '"' || c == '\\' {
+				b = append(b, c)
+			}
+			b = append(b, c)
+		}
+		b = append(b, '"')
+	}
+
+	return append(b, ')')
+}
+
+// fieldText gets field value in text format, returns false for NULL
+func fieldText(field *orm.Field, strct reflect.Value) ([]byte, bool) {
+	if field.NullZero() && field.HasZeroValue(strct) {
+		return nil, false
+	}
+
+	// nested composites are appended as ROW(...) by go-pg, so their own text format is used
+	value := field.Value(strct)
+	if value.Type().Implements(appenderType) && reflect.Indirect(value).Kind() == reflect.Struct {
+		if value.Kind() == reflect.Ptr && value.IsNil() {
+			return nil, false
+		}
+		elem, err := value.Interface().(types.ValueAppender).AppendValue(nil, 0)
+		return elem, err == nil
+	}
+
+	// NULL is appended as nothing without quote flag
+	elem := field.AppendValue(nil, strct, 0)
+	return elem, elem != nil
+}
+
+// newWrapper gets wrapper struct for type of composite
+func newWrapper(typ reflect.Type) wrapper {
+	if w, ok := wrappers.Load(typ); ok {
+		return w.(wrapper)
+	}
+
+	strct := reflect.StructOf([]reflect.StructField{{
+		Name: "Value",
+		Type: typ,
+		Tag:  `pg:"value,composite:composite"`,
+	}})
+	w := wrapper{typ: strct, field: orm.GetTable(strct).Fields[0]}
+	wrappers.Store(typ, w)
+
+	return w
+}
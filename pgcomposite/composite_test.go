@@ -0,0 +1,125 @@
+package pgcomposite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-pg/pg/v9/types"
+)
+
+type point struct {
+	X int `pg:"x"`
+	Y int `pg:"y"`
+}
+
+func (p *point) ScanValue(rd types.Reader, n int) error {
+	return Scan(p, rd, n)
+}
+
+func (p point) AppendValue(b []byte, flags int) ([]byte, error) {
+	return Append(b, p, flags), nil
+}
+
+type place struct {
+	Name   string   `pg:"name"`
+	Tags   []string `pg:"tags,array"`
+	Center point    `pg:"center,composite:point"`
+}
+
+func (p *place) ScanValue(rd types.Reader, n int) error {
+	return Scan(p, rd, n)
+}
+
+func (p place) AppendValue(b []byte, flags int) ([]byte, error) {
+	return Append(b, p, flags), nil
+}
+
+func TestAppend(t *testing.T) {
+	tests := []struct {
+		name  string
+		value []place
+		flags int
+		want  string
+	}{
+		{
+			name:  "Should append array of composites",
+			value: []place{{Name: "main", Tags: []string{"a", "b"}, Center: point{X: 1, Y: 2}}},
+			want:  `{"(\"main\",\"{\"\"a\"\",\"\"b\"\"}\",\"(\"\"1\"\",\"\"2\"\")\")"}`,
+		},
+		{
+			name:  "Should append nulls as empty fields",
+			value: []place{{}},
+			want:  `{"(,,\"(,)\")"}`,
+		},
+		{
+			name:  "Should quote array for query",
+			value: []place{{Name: "it's"}},
+			flags: 1,
+			want:  `'{"(\"it''s\",,\"(,)\")"}'`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := types.ArrayAppender(reflect.TypeOf(tt.value))(nil, reflect.ValueOf(tt.value), tt.flags)
+			if string(got) != tt.want {
+				t.Errorf("Append() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    []place
+		wantErr bool
+	}{
+		{
+			name: "Should scan array of composites",
+			text: `{"(main,\"{a,b}\",\"(1,2)\")","(\"it's \"\"quoted\"\"\",,)",NULL}`,
+			want: []place{
+				{Name: "main", Tags: []string{"a", "b"}, Center: point{X: 1, Y: 2}},
+				{Name: `it's "quoted"`},
+				{},
+			},
+		},
+		{
+			name:    "Should fail on wrong value",
+			text:    `{"(main,,\"(x,2)\")"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []place
+			err := types.ArrayScanner(reflect.TypeOf(got))(reflect.ValueOf(&got).Elem(), types.NewBytesReader([]byte(tt.text)), len(tt.text))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Scan() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppend_Scan(t *testing.T) {
+	value := []place{
+		{Name: `a "b" \c`, Tags: []string{"x y", `"z"`}, Center: point{X: -1, Y: 3}},
+		{Name: "d"},
+	}
+
+	text := types.ArrayAppender(reflect.TypeOf(value))(nil, reflect.ValueOf(value), 0)
+
+	var got []place
+	if err := types.ArrayScanner(reflect.TypeOf(got))(reflect.ValueOf(&got).Elem(), types.NewBytesReader(text), len(text)); err != nil {
+		t.Errorf("Scan() error = %v", err)
+		return
+	}
+
+	if !reflect.DeepEqual(got, value) {
+		t.Errorf("Scan(Append()) = %#v, want %#v", got, value)
+	}
+}
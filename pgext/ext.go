@@ -0,0 +1,21 @@
+// Package pgext contains go types for types of common postgres extensions
+// used by generated models: ltree and PostGIS geometry and geography
+//
+// Zero value of every type is stored as NULL
+package pgext
+
+import (
+	"fmt"
+)
+
+// text gets text representation of scanned value
+func text(src interface{}) (string, error) {
+	switch v := src.(type) {
+	case []byte:
+		return string(v), nil
+	case string:
+		return v, nil
+	}
+
+	return "", fmt.Errorf("pgext: can not scan %T", src)
+}
@@ -0,0 +1,155 @@
+package pgext
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLtree_Scan(t *testing.T) {
+	tests := []struct {
+		name string
+		src  interface{}
+		want Ltree
+	}{
+		{
+			name: "Should scan path",
+			src:  []byte("Top.Science.Astronomy"),
+			want: Ltree{"Top", "Science", "Astronomy"},
+		},
+		{
+			name: "Should scan empty path",
+			src:  "",
+			want: Ltree{},
+		},
+		{
+			name: "Should scan null as nil",
+			src:  nil,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Ltree
+			if err := got.Scan(tt.src); err != nil {
+				t.Errorf("Ltree.Scan() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Ltree.Scan() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLtree_IsAncestorOf(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  Ltree
+		other Ltree
+		want  bool
+	}{
+		{
+			name:  "Should detect ancestor",
+			path:  NewLtree("Top"),
+			other: NewLtree("Top", "Science"),
+			want:  true,
+		},
+		{
+			name:  "Should detect equal path",
+			path:  NewLtree("Top", "Science"),
+			other: NewLtree("Top", "Science"),
+			want:  true,
+		},
+		{
+			name:  "Should not detect descendant",
+			path:  NewLtree("Top", "Science"),
+			other: NewLtree("Top"),
+			want:  false,
+		},
+		{
+			name:  "Should not detect sibling",
+			path:  NewLtree("Top", "Science"),
+			other: NewLtree("Top", "Hobbies"),
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.path.IsAncestorOf(tt.other); got != tt.want {
+				t.Errorf("Ltree.IsAncestorOf() = %v, want %v", got, tt.want)
+			}
+			if got := tt.other.IsDescendantOf(tt.path); got != tt.want {
+				t.Errorf("Ltree.IsDescendantOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLtree_Value(t *testing.T) {
+	if got, _ := NewLtree("Top", "Science").Value(); got != "Top.Science" {
+		t.Errorf("Ltree.Value() = %v, want Top.Science", got)
+	}
+	if got, _ := Ltree(nil).Value(); got != nil {
+		t.Errorf("Ltree.Value() = %v, want nil", got)
+	}
+}
+
+func TestGeometry_Scan(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		wantSRID int
+		wantWKB  string
+		wantType uint32
+		wantErr  bool
+	}{
+		{
+			name:     "Should scan point with srid",
+			src:      "0101000020E6100000000000000000F03F0000000000000040",
+			wantSRID: 4326,
+			wantWKB:  "0101000000000000000000F03F0000000000000040",
+			wantType: 1,
+		},
+		{
+			name:     "Should scan big endian point without srid",
+			src:      "00000000013FF00000000000004000000000000000",
+			wantWKB:  "00000000013FF00000000000004000000000000000",
+			wantType: 1,
+		},
+		{
+			name:    "Should not scan not hex",
+			src:     "POINT(1 2)",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Geometry
+			if err := got.Scan(tt.src); (err != nil) != tt.wantErr {
+				t.Errorf("Geometry.Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.SRID != tt.wantSRID {
+				t.Errorf("Geometry.SRID = %v, want %v", got.SRID, tt.wantSRID)
+			}
+			if wkb := (Geometry{WKB: got.WKB}).String(); wkb != tt.wantWKB {
+				t.Errorf("Geometry.WKB = %v, want %v", wkb, tt.wantWKB)
+			}
+			if typ := got.Type(); typ != tt.wantType {
+				t.Errorf("Geometry.Type() = %v, want %v", typ, tt.wantType)
+			}
+			if value, _ := got.Value(); value != tt.src {
+				t.Errorf("Geometry.Value() = %v, want %v", value, tt.src)
+			}
+		})
+	}
+}
+
+func TestGeometry_Value(t *testing.T) {
+	if got, _ := (Geometry{}).Value(); got != nil {
+		t.Errorf("Geometry{}.Value() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,119 @@
+package pgext
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	// sridFlag is set in EWKB type if SRID follows it
+	sridFlag = 0x20000000
+	// flagsMask clears EWKB Z, M and SRID flags
+	flagsMask = 0x0fffffff
+)
+
+// Geometry is a go type for PostGIS geometry and geography
+// WKB is well-known binary without SRID, it is stored in postgres text format as hex EWKB
+type Geometry struct {
+	SRID int
+	WKB  []byte
+}
+
+// IsZero checks if geometry is not set, zero geometry is stored as NULL
+func (g Geometry) IsZero() bool {
+	return g.WKB == nil
+}
+
+// Type gets geometry type, e.g. 1 for point, 2 for linestring, 3 for polygon
+func (g Geometry) Type() uint32 {
+	if len(g.WKB) < 5 {
+		return 0
+	}
+
+	return g.order().Uint32(g.WKB[1:5]) & flagsMask % 1000
+}
+
+// String prints geometry as hex EWKB
+func (g Geometry) String() string {
+	return strings.ToUpper(hex.EncodeToString(g.EWKB()))
+}
+
+// EWKB gets extended well-known binary with SRID included
+func (g Geometry) EWKB() []byte {
+	if g.SRID == 0 || len(g.WKB) < 5 {
+		return g.WKB
+	}
+
+	order := g.order()
+	result := make([]byte, 0, len(g.WKB)+4)
+	result = append(result, g.WKB[:5]...)
+	order.PutUint32(result[1:5], order.Uint32(g.WKB[1:5])|sridFlag)
+	result = append(result, 0, 0, 0, 0)
+	order.PutUint32(result[5:9], uint32(g.SRID))
+
+	return append(result, g.WKB[5:]...)
+}
+
+// Value implements driver.Valuer
+func (g Geometry) Value() (driver.Value, error) {
+	if g.IsZero() {
+		return nil, nil
+	}
+	return g.String(), nil
+}
+
+// Scan implements sql.Scanner
+func (g *Geometry) Scan(src interface{}) error {
+	if src == nil {
+		*g = Geometry{}
+		return nil
+	}
+
+	s, err := text(src)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("pgext: invalid geometry: %w", err)
+	}
+
+	return g.parse(b)
+}
+
+// parse splits EWKB to SRID and WKB
+func (g *Geometry) parse(b []byte) error {
+	if len(b) < 5 || b[0] > 1 {
+		return fmt.Errorf("pgext: invalid geometry header")
+	}
+
+	*g = Geometry{WKB: b}
+
+	order := g.order()
+	typ := order.Uint32(b[1:5])
+	if typ&sridFlag == 0 {
+		return nil
+	}
+
+	if len(b) < 9 {
+		return fmt.Errorf("pgext: invalid geometry srid")
+	}
+
+	g.SRID = int(order.Uint32(b[5:9]))
+	g.WKB = append(append([]byte{}, b[:5]...), b[9:]...)
+	order.PutUint32(g.WKB[1:5], typ&^sridFlag)
+
+	return nil
+}
+
+// order gets byte order of WKB
+func (g Geometry) order() binary.ByteOrder {
+	if g.WKB[0] == 0 {
+		return binary.BigEndian
+	}
+	return binary.LittleEndian
+}
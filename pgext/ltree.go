@@ -0,0 +1,86 @@
+package pgext
+
+import (
+	"database/sql/driver"
+	"strings"
+)
+
+// Ltree is a go type for ltree, e.g. Top.Science.Astronomy is stored as [Top Science Astronomy]
+type Ltree []string
+
+// NewLtree creates path from labels
+func NewLtree(labels ...string) Ltree {
+	return append(Ltree{}, labels...)
+}
+
+// IsZero checks if path is not set, nil path is stored as NULL
+func (l Ltree) IsZero() bool {
+	return l == nil
+}
+
+// Level gets number of labels in path
+func (l Ltree) Level() int {
+	return len(l)
+}
+
+// Parent gets path without last label, parent of root is empty path
+func (l Ltree) Parent() Ltree {
+	if len(l) == 0 {
+		return Ltree{}
+	}
+	return NewLtree(l[:len(l)-1]...)
+}
+
+// IsAncestorOf checks if path is ancestor of other path or equal to it, like ltree @> operator
+func (l Ltree) IsAncestorOf(other Ltree) bool {
+	if len(l) > len(other) {
+		return false
+	}
+
+	for i, label := range l {
+		if other[i] != label {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsDescendantOf checks if path is descendant of other path or equal to it, like ltree <@ operator
+func (l Ltree) IsDescendantOf(other Ltree) bool {
+	return other.IsAncestorOf(l)
+}
+
+// String prints path in postgres text format
+func (l Ltree) String() string {
+	return strings.Join(l, ".")
+}
+
+// Value implements driver.Valuer
+func (l Ltree) Value() (driver.Value, error) {
+	if l.IsZero() {
+		return nil, nil
+	}
+	return l.String(), nil
+}
+
+// Scan implements sql.Scanner
+func (l *Ltree) Scan(src interface{}) error {
+	if src == nil {
+		*l = nil
+		return nil
+	}
+
+	s, err := text(src)
+	if err != nil {
+		return err
+	}
+
+	if s == "" {
+		*l = Ltree{}
+		return nil
+	}
+
+	*l = strings.Split(s, ".")
+	return nil
+}
@@ -0,0 +1,93 @@
+package pgrange
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// IntRange is a go type for int4range and int8range
+type IntRange struct {
+	Lower int64
+	Upper int64
+
+	// LowerInc and UpperInc are set for inclusive bounds
+	LowerInc bool
+	UpperInc bool
+
+	// LowerInf and UpperInf are set for unbounded sides, bound value is ignored
+	LowerInf bool
+	UpperInf bool
+
+	// Empty is set for empty range, bounds are ignored
+	Empty bool
+}
+
+// IsZero checks if range is not set, zero range is stored as NULL
+func (r IntRange) IsZero() bool {
+	return r == IntRange{}
+}
+
+// IsValid checks if lower bound is not greater than upper one
+func (r IntRange) IsValid() bool {
+	return r.Empty || r.LowerInf || r.UpperInf || r.Lower <= r.Upper
+}
+
+// String prints range in postgres text format
+func (r IntRange) String() string {
+	return bounds{
+		lower:    strconv.FormatInt(r.Lower, 10),
+		upper:    strconv.FormatInt(r.Upper, 10),
+		lowerInc: r.LowerInc,
+		upperInc: r.UpperInc,
+		lowerInf: r.LowerInf,
+		upperInf: r.UpperInf,
+		empty:    r.Empty,
+	}.format()
+}
+
+// Value implements driver.Valuer
+func (r IntRange) Value() (driver.Value, error) {
+	if r.IsZero() {
+		return nil, nil
+	}
+	return r.String(), nil
+}
+
+// Scan implements sql.Scanner
+func (r *IntRange) Scan(src interface{}) error {
+	if src == nil {
+		*r = IntRange{}
+		return nil
+	}
+
+	s, err := text(src)
+	if err != nil {
+		return err
+	}
+
+	return r.parse(s)
+}
+
+func (r *IntRange) parse(s string) error {
+	b, err := parse(s)
+	if err != nil {
+		return err
+	}
+
+	*r = IntRange{LowerInc: b.lowerInc, UpperInc: b.upperInc, LowerInf: b.lowerInf, UpperInf: b.upperInf, Empty: b.empty}
+
+	if !b.empty && !b.lowerInf {
+		if r.Lower, err = strconv.ParseInt(b.lower, 10, 64); err != nil {
+			return fmt.Errorf("pgrange: invalid lower bound: %w", err)
+		}
+	}
+
+	if !b.empty && !b.upperInf {
+		if r.Upper, err = strconv.ParseInt(b.upper, 10, 64); err != nil {
+			return fmt.Errorf("pgrange: invalid upper bound: %w", err)
+		}
+	}
+
+	return nil
+}
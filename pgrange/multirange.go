@@ -0,0 +1,194 @@
+package pgrange
+
+import (
+	"database/sql/driver"
+)
+
+// IntMultirange is a go type for int4multirange and int8multirange
+type IntMultirange []IntRange
+
+// IsZero checks if multirange is not set, nil multirange is stored as NULL
+func (m IntMultirange) IsZero() bool {
+	return m == nil
+}
+
+// IsValid checks if all ranges are valid
+func (m IntMultirange) IsValid() bool {
+	for _, r := range m {
+		if !r.IsValid() {
+			return false
+		}
+	}
+	return true
+}
+
+// String prints multirange in postgres text format
+func (m IntMultirange) String() string {
+	ranges := make([]string, len(m))
+	for i, r := range m {
+		ranges[i] = r.String()
+	}
+	return join(ranges)
+}
+
+// Value implements driver.Valuer
+func (m IntMultirange) Value() (driver.Value, error) {
+	if m.IsZero() {
+		return nil, nil
+	}
+	return m.String(), nil
+}
+
+// Scan implements sql.Scanner
+func (m *IntMultirange) Scan(src interface{}) error {
+	if src == nil {
+		*m = nil
+		return nil
+	}
+
+	s, err := text(src)
+	if err != nil {
+		return err
+	}
+
+	parts, err := split(s)
+	if err != nil {
+		return err
+	}
+
+	result := make(IntMultirange, len(parts))
+	for i, part := range parts {
+		if err := result[i].parse(part); err != nil {
+			return err
+		}
+	}
+
+	*m = result
+	return nil
+}
+
+// NumMultirange is a go type for nummultirange
+type NumMultirange []NumRange
+
+// IsZero checks if multirange is not set, nil multirange is stored as NULL
+func (m NumMultirange) IsZero() bool {
+	return m == nil
+}
+
+// IsValid checks if all ranges are valid
+func (m NumMultirange) IsValid() bool {
+	for _, r := range m {
+		if !r.IsValid() {
+			return false
+		}
+	}
+	return true
+}
+
+// String prints multirange in postgres text format
+func (m NumMultirange) String() string {
+	ranges := make([]string, len(m))
+	for i, r := range m {
+		ranges[i] = r.String()
+	}
+	return join(ranges)
+}
+
+// Value implements driver.Valuer
+func (m NumMultirange) Value() (driver.Value, error) {
+	if m.IsZero() {
+		return nil, nil
+	}
+	return m.String(), nil
+}
+
+// Scan implements sql.Scanner
+func (m *NumMultirange) Scan(src interface{}) error {
+	if src == nil {
+		*m = nil
+		return nil
+	}
+
+	s, err := text(src)
+	if err != nil {
+		return err
+	}
+
+	parts, err := split(s)
+	if err != nil {
+		return err
+	}
+
+	result := make(NumMultirange, len(parts))
+	for i, part := range parts {
+		if err := result[i].parse(part); err != nil {
+			return err
+		}
+	}
+
+	*m = result
+	return nil
+}
+
+// TimeMultirange is a go type for tsmultirange, tstzmultirange and datemultirange
+type TimeMultirange []TimeRange
+
+// IsZero checks if multirange is not set, nil multirange is stored as NULL
+func (m TimeMultirange) IsZero() bool {
+	return m == nil
+}
+
+// IsValid checks if all ranges are valid
+func (m TimeMultirange) IsValid() bool {
+	for _, r := range m {
+		if !r.IsValid() {
+			return false
+		}
+	}
+	return true
+}
+
+// String prints multirange in postgres text format
+func (m TimeMultirange) String() string {
+	ranges := make([]string, len(m))
+	for i, r := range m {
+		ranges[i] = r.String()
+	}
+	return join(ranges)
+}
+
+// Value implements driver.Valuer
+func (m TimeMultirange) Value() (driver.Value, error) {
+	if m.IsZero() {
+		return nil, nil
+	}
+	return m.String(), nil
+}
+
+// Scan implements sql.Scanner
+func (m *TimeMultirange) Scan(src interface{}) error {
+	if src == nil {
+		*m = nil
+		return nil
+	}
+
+	s, err := text(src)
+	if err != nil {
+		return err
+	}
+
+	parts, err := split(s)
+	if err != nil {
+		return err
+	}
+
+	result := make(TimeMultirange, len(parts))
+	for i, part := range parts {
+		if err := result[i].parse(part); err != nil {
+			return err
+		}
+	}
+
+	*m = result
+	return nil
+}
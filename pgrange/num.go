@@ -0,0 +1,93 @@
+package pgrange
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// NumRange is a go type for numrange
+type NumRange struct {
+	Lower float64
+	Upper float64
+
+	// LowerInc and UpperInc are set for inclusive bounds
+	LowerInc bool
+	UpperInc bool
+
+	// LowerInf and UpperInf are set for unbounded sides, bound value is ignored
+	LowerInf bool
+	UpperInf bool
+
+	// Empty is set for empty range, bounds are ignored
+	Empty bool
+}
+
+// IsZero checks if range is not set, zero range is stored as NULL
+func (r NumRange) IsZero() bool {
+	return r == NumRange{}
+}
+
+// IsValid checks if lower bound is not greater than upper one
+func (r NumRange) IsValid() bool {
+	return r.Empty || r.LowerInf || r.UpperInf || r.Lower <= r.Upper
+}
+
+// String prints range in postgres text format
+func (r NumRange) String() string {
+	return bounds{
+		lower:    strconv.FormatFloat(r.Lower, 'f', -1, 64),
+		upper:    strconv.FormatFloat(r.Upper, 'f', -1, 64),
+		lowerInc: r.LowerInc,
+		upperInc: r.UpperInc,
+		lowerInf: r.LowerInf,
+		upperInf: r.UpperInf,
+		empty:    r.Empty,
+	}.format()
+}
+
+// Value implements driver.Valuer
+func (r NumRange) Value() (driver.Value, error) {
+	if r.IsZero() {
+		return nil, nil
+	}
+	return r.String(), nil
+}
+
+// Scan implements sql.Scanner
+func (r *NumRange) Scan(src interface{}) error {
+	if src == nil {
+		*r = NumRange{}
+		return nil
+	}
+
+	s, err := text(src)
+	if err != nil {
+		return err
+	}
+
+	return r.parse(s)
+}
+
+func (r *NumRange) parse(s string) error {
+	b, err := parse(s)
+	if err != nil {
+		return err
+	}
+
+	*r = NumRange{LowerInc: b.lowerInc, UpperInc: b.upperInc, LowerInf: b.lowerInf, UpperInf: b.upperInf, Empty: b.empty}
+
+	if !b.empty && !b.lowerInf {
+		if r.Lower, err = strconv.ParseFloat(b.lower, 64); err != nil {
+			return fmt.Errorf("pgrange: invalid lower bound: %w", err)
+		}
+	}
+
+	if !b.empty && !b.upperInf {
+		if r.Upper, err = strconv.ParseFloat(b.upper, 64); err != nil {
+			return fmt.Errorf("pgrange: invalid upper bound: %w", err)
+		}
+	}
+
+	return nil
+}
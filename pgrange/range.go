@@ -0,0 +1,188 @@
+// Package pgrange contains go types for postgres range and multirange types
+// used by generated models
+//
+// Zero value of every range is stored as NULL, use Empty for empty ranges
+package pgrange
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const empty = "empty"
+
+// bounds stores range parsed from postgres text format, e.g. [1,5) or ["2020-01-01 10:00:00+00",)
+type bounds struct {
+	lower, upper       string
+	lowerInc, upperInc bool
+	lowerInf, upperInf bool
+	empty              bool
+}
+
+// parse parses range from postgres text format
+func parse(s string) (bounds, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, empty) {
+		return bounds{empty: true}, nil
+	}
+
+	if len(s) < 3 {
+		return bounds{}, fmt.Errorf("pgrange: invalid range %q", s)
+	}
+
+	var b bounds
+	switch s[0] {
+	case '[':
+		b.lowerInc = true
+	case '(':
+	default:
+		return bounds{}, fmt.Errorf("pgrange: invalid lower bound in %q", s)
+	}
+
+	switch s[len(s)-1] {
+	case ']':
+		b.upperInc = true
+	case ')':
+	default:
+		return bounds{}, fmt.Errorf("pgrange: invalid upper bound in %q", s)
+	}
+
+	lower, rest, err := bound(s[1 : len(s)-1])
+	if err != nil {
+		return bounds{}, fmt.Errorf("pgrange: invalid range %q: %w", s, err)
+	}
+
+	if !strings.HasPrefix(rest, ",") {
+		return bounds{}, fmt.Errorf("pgrange: invalid range %q: missing comma", s)
+	}
+
+	upper, rest, err := bound(rest[1:])
+	if err != nil {
+		return bounds{}, fmt.Errorf("pgrange: invalid range %q: %w", s, err)
+	}
+
+	if rest != "" {
+		return bounds{}, fmt.Errorf("pgrange: invalid range %q: unexpected %q", s, rest)
+	}
+
+	b.lower, b.lowerInf = lower, lower == ""
+	b.upper, b.upperInf = upper, upper == ""
+
+	// infinite bounds are never inclusive
+	b.lowerInc = b.lowerInc && !b.lowerInf
+	b.upperInc = b.upperInc && !b.upperInf
+
+	return b, nil
+}
+
+// bound reads one bound, it can be quoted and escaped by backslashes
+// returns bound value and rest of string
+func bound(s string) (string, string, error) {
+	if !strings.HasPrefix(s, `"`) {
+		i := strings.IndexByte(s, ',')
+		if i < 0 {
+			return s, "", nil
+		}
+		return s[:i], s[i:], nil
+	}
+
+	var value strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && i+1 < len(s):
+			i++
+			value.WriteByte(s[i])
+		case c == '"' && i+1 < len(s) && s[i+1] == '"':
+			i++
+			value.WriteByte('"')
+		case c == '"':
+			return value.String(), s[i+1:], nil
+		default:
+			value.WriteByte(c)
+		}
+	}
+
+	return "", "", errors.New("unterminated quoted bound")
+}
+
+// format prints range in postgres text format, bounds are quoted
+func (b bounds) format() string {
+	if b.empty {
+		return empty
+	}
+
+	var sb strings.Builder
+	if b.lowerInc && !b.lowerInf {
+		sb.WriteByte('[')
+	} else {
+		sb.WriteByte('(')
+	}
+
+	if !b.lowerInf {
+		sb.WriteString(quote(b.lower))
+	}
+	sb.WriteByte(',')
+	if !b.upperInf {
+		sb.WriteString(quote(b.upper))
+	}
+
+	if b.upperInc && !b.upperInf {
+		sb.WriteByte(']')
+	} else {
+		sb.WriteByte(')')
+	}
+
+	return sb.String()
+}
+
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// split splits multirange text format, e.g. {[1,3),[5,7)}, to ranges
+func split(s string) ([]string, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+		return nil, fmt.Errorf("pgrange: invalid multirange %q", s)
+	}
+
+	s = strings.TrimSpace(s[1 : len(s)-1])
+	if s == "" {
+		return []string{}, nil
+	}
+
+	var parts []string
+	start, quoted := 0, false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && quoted:
+			i++
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == ',' && (s[i-1] == ']' || s[i-1] == ')'):
+			parts = append(parts, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+
+	return append(parts, strings.TrimSpace(s[start:])), nil
+}
+
+// join prints multirange in postgres text format
+func join(ranges []string) string {
+	return "{" + strings.Join(ranges, ",") + "}"
+}
+
+// text gets string from value passed to Scan
+func text(src interface{}) (string, error) {
+	switch v := src.(type) {
+	case []byte:
+		return string(v), nil
+	case string:
+		return v, nil
+	}
+
+	return "", fmt.Errorf("pgrange: can not scan %T", src)
+}
@@ -0,0 +1,299 @@
+package pgrange
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    bounds
+		wantErr bool
+	}{
+		{
+			name: "Should parse canonical range",
+			s:    "[1,5)",
+			want: bounds{lower: "1", upper: "5", lowerInc: true},
+		},
+		{
+			name: "Should parse empty range",
+			s:    "empty",
+			want: bounds{empty: true},
+		},
+		{
+			name: "Should parse unbounded sides",
+			s:    "(,5]",
+			want: bounds{upper: "5", upperInc: true, lowerInf: true},
+		},
+		{
+			name: "Should parse quoted bounds",
+			s:    `["2020-01-01 10:00:00+00","2020-01-02 00:00:00+00")`,
+			want: bounds{lower: "2020-01-01 10:00:00+00", upper: "2020-01-02 00:00:00+00", lowerInc: true},
+		},
+		{
+			name: "Should parse escaped bounds",
+			s:    `["a\"b","c""d"]`,
+			want: bounds{lower: `a"b`, upper: `c"d`, lowerInc: true, upperInc: true},
+		},
+		{
+			name:    "Should not parse without brackets",
+			s:       "1,5",
+			wantErr: true,
+		},
+		{
+			name:    "Should not parse unterminated quotes",
+			s:       `["1,5)`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parse(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntRange_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    IntRange
+		wantErr bool
+	}{
+		{
+			name: "Should scan range",
+			src:  []byte("[1,10)"),
+			want: IntRange{Lower: 1, Upper: 10, LowerInc: true},
+		},
+		{
+			name: "Should scan null as zero",
+			src:  nil,
+			want: IntRange{},
+		},
+		{
+			name: "Should scan unbounded range",
+			src:  "[-5,)",
+			want: IntRange{Lower: -5, LowerInc: true, UpperInf: true},
+		},
+		{
+			name:    "Should not scan not a number",
+			src:     "[a,5)",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got IntRange
+			if err := got.Scan(tt.src); (err != nil) != tt.wantErr {
+				t.Errorf("IntRange.Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("IntRange.Scan() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntRange_Value(t *testing.T) {
+	tests := []struct {
+		name  string
+		r     IntRange
+		want  interface{}
+		valid bool
+	}{
+		{
+			name:  "Should store zero as null",
+			r:     IntRange{},
+			want:  nil,
+			valid: true,
+		},
+		{
+			name:  "Should store empty range",
+			r:     IntRange{Empty: true},
+			want:  "empty",
+			valid: true,
+		},
+		{
+			name:  "Should store range",
+			r:     IntRange{Lower: 1, Upper: 10, LowerInc: true, UpperInc: true},
+			want:  `["1","10"]`,
+			valid: true,
+		},
+		{
+			name:  "Should store unbounded range",
+			r:     IntRange{Upper: 10, LowerInf: true, LowerInc: true},
+			want:  `(,"10")`,
+			valid: true,
+		},
+		{
+			name: "Should detect invalid range",
+			r:    IntRange{Lower: 10, Upper: 1},
+			want: `("10","1")`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.r.Value()
+			if err != nil {
+				t.Errorf("IntRange.Value() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IntRange.Value() = %v, want %v", got, tt.want)
+			}
+			if valid := tt.r.IsValid(); valid != tt.valid {
+				t.Errorf("IntRange.IsValid() = %v, want %v", valid, tt.valid)
+			}
+		})
+	}
+}
+
+func TestNumRange_Scan(t *testing.T) {
+	var got NumRange
+	if err := got.Scan([]byte("(0.5,2.25]")); err != nil {
+		t.Errorf("NumRange.Scan() error = %v", err)
+		return
+	}
+
+	want := NumRange{Lower: 0.5, Upper: 2.25, UpperInc: true}
+	if got != want {
+		t.Errorf("NumRange.Scan() = %+v, want %+v", got, want)
+	}
+
+	if s := got.String(); s != `("0.5","2.25"]` {
+		t.Errorf("NumRange.String() = %v, want %v", s, `("0.5","2.25"]`)
+	}
+}
+
+func TestTimeRange_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    TimeRange
+		wantErr bool
+	}{
+		{
+			name: "Should scan tstzrange",
+			src:  `["2020-01-01 10:00:00+03","2020-01-02 00:00:00.5+03")`,
+			want: TimeRange{
+				Lower:    time.Date(2020, 1, 1, 7, 0, 0, 0, time.UTC),
+				Upper:    time.Date(2020, 1, 1, 21, 0, 0, 500000000, time.UTC),
+				LowerInc: true,
+			},
+		},
+		{
+			name: "Should scan tsrange",
+			src:  `("2020-01-01 10:00:00",)`,
+			want: TimeRange{Lower: time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC), UpperInf: true},
+		},
+		{
+			name: "Should scan daterange",
+			src:  `[2020-01-01,2020-01-05)`,
+			want: TimeRange{Lower: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Upper: time.Date(2020, 1, 5, 0, 0, 0, 0, time.UTC), LowerInc: true},
+		},
+		{
+			name:    "Should not scan infinity",
+			src:     `[2020-01-01,infinity)`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got TimeRange
+			if err := got.Scan(tt.src); (err != nil) != tt.wantErr {
+				t.Errorf("TimeRange.Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !got.Lower.Equal(tt.want.Lower) || !got.Upper.Equal(tt.want.Upper) ||
+				got.LowerInc != tt.want.LowerInc || got.UpperInc != tt.want.UpperInc ||
+				got.LowerInf != tt.want.LowerInf || got.UpperInf != tt.want.UpperInf {
+				t.Errorf("TimeRange.Scan() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeRange_Value(t *testing.T) {
+	r := TimeRange{
+		Lower:    time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+		Upper:    time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		LowerInc: true,
+	}
+
+	got, err := r.Value()
+	if err != nil {
+		t.Errorf("TimeRange.Value() error = %v", err)
+		return
+	}
+
+	want := `["2020-01-01 10:00:00+00:00","2020-01-02 00:00:00+00:00")`
+	if got != want {
+		t.Errorf("TimeRange.Value() = %v, want %v", got, want)
+	}
+
+	if zero, _ := (TimeRange{}).Value(); zero != nil {
+		t.Errorf("TimeRange{}.Value() = %v, want nil", zero)
+	}
+}
+
+func TestMultirange_Scan(t *testing.T) {
+	t.Run("Should scan int multirange", func(t *testing.T) {
+		var got IntMultirange
+		if err := got.Scan([]byte("{[1,3),[5,7)}")); err != nil {
+			t.Errorf("IntMultirange.Scan() error = %v", err)
+			return
+		}
+
+		want := IntMultirange{{Lower: 1, Upper: 3, LowerInc: true}, {Lower: 5, Upper: 7, LowerInc: true}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("IntMultirange.Scan() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("Should scan empty multirange", func(t *testing.T) {
+		var got NumMultirange
+		if err := got.Scan("{}"); err != nil {
+			t.Errorf("NumMultirange.Scan() error = %v", err)
+			return
+		}
+
+		if got == nil || len(got) != 0 {
+			t.Errorf("NumMultirange.Scan() = %#v, want empty", got)
+		}
+	})
+
+	t.Run("Should scan quoted time multirange", func(t *testing.T) {
+		var got TimeMultirange
+		if err := got.Scan(`{["2020-01-01 10:00:00","2020-01-01 12:00:00"),["2020-01-02 10:00:00",)}`); err != nil {
+			t.Errorf("TimeMultirange.Scan() error = %v", err)
+			return
+		}
+
+		if len(got) != 2 || !got[1].UpperInf {
+			t.Errorf("TimeMultirange.Scan() = %+v, want 2 ranges", got)
+		}
+	})
+
+	t.Run("Should store nil multirange as null", func(t *testing.T) {
+		if got, _ := IntMultirange(nil).Value(); got != nil {
+			t.Errorf("IntMultirange.Value() = %v, want nil", got)
+		}
+		if got, _ := (IntMultirange{}).Value(); got != "{}" {
+			t.Errorf("IntMultirange.Value() = %v, want {}", got)
+		}
+	})
+}
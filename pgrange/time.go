@@ -0,0 +1,117 @@
+package pgrange
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// timeFormat is used to print bounds, it is accepted by tsrange, tstzrange and daterange
+const timeFormat = "2006-01-02 15:04:05.999999999-07:00"
+
+// timeLayouts are postgres output formats of timestamp, timestamptz and date
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00:00",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// TimeRange is a go type for tsrange, tstzrange and daterange
+type TimeRange struct {
+	Lower time.Time
+	Upper time.Time
+
+	// LowerInc and UpperInc are set for inclusive bounds
+	LowerInc bool
+	UpperInc bool
+
+	// LowerInf and UpperInf are set for unbounded sides, bound value is ignored
+	LowerInf bool
+	UpperInf bool
+
+	// Empty is set for empty range, bounds are ignored
+	Empty bool
+}
+
+// IsZero checks if range is not set, zero range is stored as NULL
+func (r TimeRange) IsZero() bool {
+	return r.Lower.IsZero() && r.Upper.IsZero() && !r.LowerInc && !r.UpperInc && !r.LowerInf && !r.UpperInf && !r.Empty
+}
+
+// IsValid checks if lower bound is not after upper one
+func (r TimeRange) IsValid() bool {
+	return r.Empty || r.LowerInf || r.UpperInf || !r.Lower.After(r.Upper)
+}
+
+// String prints range in postgres text format
+func (r TimeRange) String() string {
+	return bounds{
+		lower:    r.Lower.Format(timeFormat),
+		upper:    r.Upper.Format(timeFormat),
+		lowerInc: r.LowerInc,
+		upperInc: r.UpperInc,
+		lowerInf: r.LowerInf,
+		upperInf: r.UpperInf,
+		empty:    r.Empty,
+	}.format()
+}
+
+// Value implements driver.Valuer
+func (r TimeRange) Value() (driver.Value, error) {
+	if r.IsZero() {
+		return nil, nil
+	}
+	return r.String(), nil
+}
+
+// Scan implements sql.Scanner
+func (r *TimeRange) Scan(src interface{}) error {
+	if src == nil {
+		*r = TimeRange{}
+		return nil
+	}
+
+	s, err := text(src)
+	if err != nil {
+		return err
+	}
+
+	return r.parse(s)
+}
+
+func (r *TimeRange) parse(s string) error {
+	b, err := parse(s)
+	if err != nil {
+		return err
+	}
+
+	*r = TimeRange{LowerInc: b.lowerInc, UpperInc: b.upperInc, LowerInf: b.lowerInf, UpperInf: b.upperInf, Empty: b.empty}
+
+	if !b.empty && !b.lowerInf {
+		if r.Lower, err = parseTime(b.lower); err != nil {
+			return fmt.Errorf("pgrange: invalid lower bound: %w", err)
+		}
+	}
+
+	if !b.empty && !b.upperInf {
+		if r.Upper, err = parseTime(b.upper); err != nil {
+			return fmt.Errorf("pgrange: invalid upper bound: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// parseTime parses bound in any of postgres formats
+// infinity can not be represented by time.Time, so it is an error
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported time %q", s)
+}
@@ -0,0 +1,105 @@
+package util
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// RegexpPrefix marks pattern as regular expression matched against full table name
+const RegexpPrefix = "re:"
+
+// Matcher matches tables with names and patterns
+// pattern is a glob for schema and table, e.g. public.audit_* or tenant_*.users,
+// or a regular expression for full name with re: prefix, e.g. re:^geo\..*_v\d+$
+type Matcher struct {
+	names   Set
+	globs   [][2]string
+	regexps []*regexp.Regexp
+}
+
+// NewMatcher creates Matcher, tables without schema are searched in public schema
+func NewMatcher(patterns []string) (Matcher, error) {
+	m := Matcher{names: NewSet()}
+
+	for _, p := range patterns {
+		switch {
+		case strings.HasPrefix(p, RegexpPrefix):
+			re, err := regexp.Compile(strings.TrimPrefix(p, RegexpPrefix))
+			if err != nil {
+				return Matcher{}, fmt.Errorf("invalid table pattern %s: %w", p, err)
+			}
+			m.regexps = append(m.regexps, re)
+		case IsGlob(p):
+			schema, table := Split(p)
+			for _, glob := range []string{schema, table} {
+				if _, err := path.Match(glob, ""); err != nil {
+					return Matcher{}, fmt.Errorf("invalid table pattern %s: %w", p, err)
+				}
+			}
+			m.globs = append(m.globs, [2]string{schema, table})
+		default:
+			m.names.Add(Join(Split(p)))
+		}
+	}
+
+	return m, nil
+}
+
+// IsGlob checks if table name contains glob characters
+func IsGlob(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
+
+// MatchName checks if table is listed by name
+func (m Matcher) MatchName(schema, table string) bool {
+	return m.names.Exists(Join(schema, table))
+}
+
+// Match checks if table is listed by name or matches any pattern
+func (m Matcher) Match(schema, table string) bool {
+	return m.MatchName(schema, table) || m.MatchPattern(schema, table)
+}
+
+// MatchPattern checks if table matches any glob or regular expression
+func (m Matcher) MatchPattern(schema, table string) bool {
+	for _, glob := range m.globs {
+		// patterns are validated in constructor
+		s, _ := path.Match(glob[0], schema)
+		t, _ := path.Match(glob[1], table)
+		if s && t {
+			return true
+		}
+	}
+
+	for _, re := range m.regexps {
+		if re.MatchString(Join(schema, table)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Schemas gets schemas of listed tables and patterns, returns false if patterns can match any schema
+func (m Matcher) Schemas() ([]string, bool) {
+	if len(m.regexps) > 0 {
+		return nil, false
+	}
+
+	schemas := NewSet()
+	for _, name := range m.names.Elements() {
+		schema, _ := Split(name)
+		schemas.Add(schema)
+	}
+
+	for _, glob := range m.globs {
+		if IsGlob(glob[0]) {
+			return nil, false
+		}
+		schemas.Add(glob[0])
+	}
+
+	return schemas.Elements(), true
+}
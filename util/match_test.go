@@ -0,0 +1,156 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatcher_Match(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		schema   string
+		table    string
+		want     bool
+	}{
+		{
+			name:     "Should match table name",
+			patterns: []string{"public.users"},
+			schema:   "public",
+			table:    "users",
+			want:     true,
+		},
+		{
+			name:     "Should match table without schema in public",
+			patterns: []string{"users"},
+			schema:   "public",
+			table:    "users",
+			want:     true,
+		},
+		{
+			name:     "Should match every table in schema",
+			patterns: []string{"public.*"},
+			schema:   "public",
+			table:    "users",
+			want:     true,
+		},
+		{
+			name:     "Should match table glob",
+			patterns: []string{"public.audit_*"},
+			schema:   "public",
+			table:    "audit_log",
+			want:     true,
+		},
+		{
+			name:     "Should match schema glob",
+			patterns: []string{"tenant_*.users"},
+			schema:   "tenant_1",
+			table:    "users",
+			want:     true,
+		},
+		{
+			name:     "Should not match glob in other schema",
+			patterns: []string{"public.audit_*"},
+			schema:   "geo",
+			table:    "audit_log",
+			want:     false,
+		},
+		{
+			name:     "Should match regular expression",
+			patterns: []string{`re:^geo\..*_v\d+$`},
+			schema:   "geo",
+			table:    "cities_v2",
+			want:     true,
+		},
+		{
+			name:     "Should not match regular expression",
+			patterns: []string{`re:^geo\..*_v\d+$`},
+			schema:   "geo",
+			table:    "cities",
+			want:     false,
+		},
+		{
+			name:     "Should not match without patterns",
+			patterns: nil,
+			schema:   "public",
+			table:    "users",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMatcher(tt.patterns)
+			if err != nil {
+				t.Errorf("NewMatcher() error = %v", err)
+				return
+			}
+			if got := m.Match(tt.schema, tt.table); got != tt.want {
+				t.Errorf("Matcher.Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatcher_Schemas(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+		wantOk   bool
+	}{
+		{
+			name:     "Should get schemas of names and globs",
+			patterns: []string{"users", "geo.cities", "geo.*", "bench.t*"},
+			want:     []string{"public", "geo", "bench"},
+			wantOk:   true,
+		},
+		{
+			name:     "Should match any schema with schema glob",
+			patterns: []string{"users", "*.users"},
+		},
+		{
+			name:     "Should match any schema with regular expression",
+			patterns: []string{"users", `re:^geo\..*$`},
+		},
+		{
+			name:   "Should get no schemas without patterns",
+			wantOk: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMatcher(tt.patterns)
+			if err != nil {
+				t.Errorf("NewMatcher() error = %v", err)
+				return
+			}
+			got, ok := m.Schemas()
+			if ok != tt.wantOk || len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+				t.Errorf("Matcher.Schemas() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestNewMatcher(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+	}{
+		{
+			name:    "Should not create matcher with invalid regular expression",
+			pattern: "re:users_(",
+		},
+		{
+			name:    "Should not create matcher with invalid glob",
+			pattern: "public.users_[",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMatcher([]string{tt.pattern}); err == nil {
+				t.Errorf("NewMatcher() error = nil, want error")
+			}
+		})
+	}
+}
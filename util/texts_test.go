@@ -492,3 +492,34 @@ func TestLowerFirst(t *testing.T) {
 		})
 	}
 }
+
+func TestComment(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "Should return empty string for empty text",
+			s:    "  ",
+			want: "",
+		},
+		{
+			name: "Should comment single line",
+			s:    "Registered users",
+			want: "// Registered users",
+		},
+		{
+			name: "Should comment every line",
+			s:    "Registered users\n\nsee docs ",
+			want: "// Registered users\n//\n// see docs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Comment(tt.s); got != tt.want {
+				t.Errorf("Comment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}